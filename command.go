@@ -0,0 +1,231 @@
+package goline
+
+/*
+ *  Filename:    command.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:11:05 UTC 2026
+ *  Description: Shell menu commands receiving their arguments as words or flags.
+ */
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+//  Split line into words the way a POSIX shell does, without expansions.
+//  Words are separated by whitespace. Text within single quotes is taken
+//  literally. Within double quotes a backslash escapes only '"', '\\', '$'
+//  and '`'. Elsewhere a backslash escapes any rune. An empty quoted string
+//  is an empty word. ErrorUnterminatedQuote is returned if a quote is not
+//  closed.
+//      words, _ := goline.SplitWords(`deploy "my app" --tag='v1 rc' a\ b`)
+//      // []string{"deploy", "my app", "--tag=v1 rc", "a b"}
+func SplitWords(line string) ([]string, error) {
+	var words []string
+	var word []rune
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, c := range line {
+		switch {
+		case escaped:
+			if quote == '"' && !strings.ContainsRune("\"\\$`", c) {
+				word = append(word, '\\')
+			}
+			word = append(word, c)
+			escaped = false
+		case quote == '\'' && c != '\'':
+			word = append(word, c)
+		case c == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == '"':
+			word = append(word, c)
+		case c == '\'' || c == '"':
+			quote, inWord = c, true
+		case unicode.IsSpace(c):
+			if inWord {
+				words = append(words, string(word))
+			}
+			word, inWord = word[:0], false
+		default:
+			word, inWord = append(word, c), true
+		}
+	}
+	switch {
+	case quote != 0:
+		return nil, ErrorUnterminatedQuote
+	case escaped:
+		// A trailing backslash is taken literally.
+		word = append(word, '\\')
+	}
+	if inWord {
+		words = append(words, string(word))
+	}
+	return words, nil
+}
+
+//  A choice running a command with the words of its arguments.
+type commandChoice struct {
+	Stringer
+	run   func(argv []string) error
+	usage string
+}
+
+//  Append a choice running cmd when it is chosen. The arguments typed after
+//  the name of the choice in a Shell menu are split with SplitWords, and cmd
+//  is given the name of the choice (even when it was chosen by its index)
+//  followed by the words as argv. If cmd returns a RecoverableError it is
+//  printed and the user is prompted again, other errors are returned by
+//  ChooseContext (see Choose).
+//      goline.Choose(func(m *goline.Menu) {
+//          m.Shell = true
+//          m.Command("deploy", func(argv []string) error {
+//              if len(argv) != 2 {
+//                  return goline.NewErrorRecoverable("usage: deploy ENV")
+//              }
+//              return deploy(argv[1])
+//          })
+//      })
+func (m *Menu) Command(name interface{}, cmd func(argv []string) error) {
+	m.Choice(commandChoice{Stringer: makeStringer(name), run: cmd}, nil)
+}
+
+//  Run the action of the choice at index i, chosen by name with arguments
+//  args. The chosen value is returned.
+func (m *Menu) act(i int, name, args string) (interface{}, error) {
+	if cmd, ok := m.Choices[i].(commandChoice); ok {
+		argv, err := SplitWords(args)
+		if err != nil {
+			return cmd.Stringer, err
+		}
+		// The choice may have been selected by its index.
+		return cmd.Stringer, cmd.run(append([]string{cmd.String()}, argv...))
+	}
+	if m.Actions[i] != nil {
+		m.Actions[i](name, args)
+	}
+	return m.Choices[i], nil
+}
+
+//  Append a choice running cmd with flags and arguments parsed by fs when it
+//  is chosen (see Menu.Command). The flags set by a command are reset to
+//  their defaults (by calling Set with the default's text) before the next
+//  command is parsed, so flags accumulating values, like those defined with
+//  fs.Func, are not supported. An error resetting a flag is returned by
+//  ChooseContext. The command finds the remaining arguments in fs.Args().
+//  The args spec names the arguments for the usage line, and limits their
+//  number: optional arguments are enclosed in brackets and an argument
+//  followed by "..." may be repeated.
+//      fs := flag.NewFlagSet("deploy", flag.ContinueOnError)
+//      env := fs.String("env", "staging", "target environment")
+//      force := fs.Bool("force", false, "skip checks")
+//      m.CommandFlags("deploy", fs, "APP...", func(fs *flag.FlagSet) error {
+//          return deploy(*env, *force, fs.Args())
+//      })
+//  Invalid flags or arguments are reported as an ErrorUsage, and "-h" shows
+//  the usage line and the flag defaults, before the user is prompted again.
+//  The error handling of fs is set to flag.ContinueOnError and its output is
+//  discarded.
+func (m *Menu) CommandFlags(name interface{}, fs *flag.FlagSet, args string, cmd func(fs *flag.FlagSet) error) {
+	fs.Init(fs.Name(), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	min, max := argCounts(args)
+	c := makeStringer(name)
+	usage := "usage: " + CommandUsage(c.String(), fs, args)
+	run := func(argv []string) error {
+		// Only flags set by earlier commands need resetting.
+		var err error
+		fs.Visit(func(f *flag.Flag) {
+			if e := f.Value.Set(f.DefValue); e != nil && err == nil {
+				err = fmt.Errorf("Can not reset flag -%s: %w", f.Name, e)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		err = fs.Parse(argv[1:])
+		switch n := fs.NArg(); {
+		case err == flag.ErrHelp:
+			return ErrorUsage{err, usage + "\n" + flagDefaults(fs)}
+		case err != nil:
+			return ErrorUsage{err, usage}
+		case n < min:
+			return ErrorUsage{errors.New("Too few arguments"), usage}
+		case max >= 0 && n > max:
+			return ErrorUsage{errors.New("Too many arguments"), usage}
+		}
+		return cmd(fs)
+	}
+	m.Choice(commandChoice{c, run, strings.TrimPrefix(usage, "usage: ")}, nil)
+}
+
+//  A usage line for a command named name, with the flags of fs and the args
+//  spec of Menu.CommandFlags.
+//      goline.CommandUsage("deploy", fs, "APP...")
+//      // "deploy [-env string] [-force] APP..."
+func CommandUsage(name string, fs *flag.FlagSet, args string) string {
+	words := []string{name}
+	fs.VisitAll(func(f *flag.Flag) {
+		// Boolean flags take no value.
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			words = append(words, fmt.Sprintf("[-%s]", f.Name))
+			return
+		}
+		typ, _ := flag.UnquoteUsage(f)
+		words = append(words, fmt.Sprintf("[-%s %s]", f.Name, typ))
+	})
+	if args = strings.TrimSpace(args); args != "" {
+		words = append(words, args)
+	}
+	return strings.Join(words, " ")
+}
+
+//  The defaults of the flags of fs, as printed by fs.PrintDefaults.
+func flagDefaults(fs *flag.FlagSet) string {
+	b := new(bytes.Buffer)
+	fs.SetOutput(b)
+	fs.PrintDefaults()
+	fs.SetOutput(io.Discard)
+	return strings.TrimRight(b.String(), "\n")
+}
+
+//  The minimum and maximum number of arguments allowed by an args spec. The
+//  maximum is -1 if there is none.
+func argCounts(args string) (min, max int) {
+	for _, arg := range strings.Fields(args) {
+		switch {
+		case strings.Contains(arg, "..."):
+			if !strings.HasPrefix(arg, "[") {
+				min++
+			}
+			max = -1
+		case strings.HasPrefix(arg, "["):
+			if max >= 0 {
+				max++
+			}
+		default:
+			min++
+			if max >= 0 {
+				max++
+			}
+		}
+	}
+	return min, max
+}
+
+//  Report a recoverable error returned by a command before prompting again.
+//  A request for help (the "-h" flag) prints the command's usage.
+func (s *Session) commandError(err error) {
+	if e, ok := err.(ErrorUsage); ok && e.Err == flag.ErrHelp {
+		s.Say(EscapeMarkup(e.Usage) + "\n")
+		return
+	}
+	s.Say(Style("Error: "+EscapeMarkup(err.Error()), "error") + "\n")
+}
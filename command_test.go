@@ -0,0 +1,199 @@
+package goline
+/*
+ *  Filename:    command_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:11:05 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "errors"
+    "flag"
+    "fmt"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+func TestSplitWords(T *testing.T) {
+    for _, test := range []struct {
+        line   string
+        expect []string
+    }{
+        {"", nil},
+        {"  a  b\tc ", []string{"a", "b", "c"}},
+        {`deploy "my app" --tag='v1 rc' a\ b`, []string{"deploy", "my app", "--tag=v1 rc", "a b"}},
+        {`'it'\''s' "say \"hi\"" "a\b"`, []string{"it's", `say "hi"`, `a\b`}},
+        {`'' "" x`, []string{"", "", "x"}},
+        {`a"b"'c'd`, []string{"abcd"}},
+        {`'\' end\`, []string{`\`, `end\`}},
+    } {
+        words, err := SplitWords(test.line)
+        if err != nil || !reflect.DeepEqual(words, test.expect) {
+            T.Errorf("%#v: Unexpected words %#v %v", test.line, words, err)
+        }
+    }
+    for _, line := range []string{`"open`, `it's`, `"esc\"`} {
+        if _, err := SplitWords(line); err != ErrorUnterminatedQuote {
+            T.Errorf("%#v: Unexpected error %v", line, err)
+        }
+    }
+}
+
+func TestMenuCommand(T *testing.T) {
+    var argvs [][]string
+    out := new(bytes.Buffer)
+    input := "deploy \"my app\n" + "deploy\n" + "dep \"my app\" 'v 2'\n"
+    s := NewSession(strings.NewReader(input), out)
+    i, v := s.Choose(func(m *Menu) {
+        m.Shell = true
+        m.Command("deploy", func(argv []string) error {
+            argvs = append(argvs, argv)
+            if len(argv) < 2 {
+                return NewErrorRecoverable("usage: deploy APP [VERSION]")
+            }
+            return nil
+        })
+        m.Choice("status", nil)
+    })
+    if i != 0 || v.(Stringer).String() != "deploy" {
+        T.Errorf("Unexpected choice %d %v", i, v)
+    }
+    expect := [][]string{{"deploy"}, {"deploy", "my app", "v 2"}}
+    if !reflect.DeepEqual(argvs, expect) {
+        T.Errorf("Unexpected argv %#v", argvs)
+    }
+    for _, msg := range []string{"Unterminated quote", "usage: deploy"} {
+        if !strings.Contains(out.String(), msg) {
+            T.Errorf("Error %#v not printed %#v", msg, out.String())
+        }
+    }
+
+    fail := errors.New("fail")
+    s = NewSession(strings.NewReader("deploy\n"), new(bytes.Buffer))
+    _, _, err := s.ChooseContext(context.Background(), func(m *Menu) {
+        m.Command("deploy", func(argv []string) error { return fail })
+    })
+    if err != fail {
+        T.Errorf("Unexpected error %v", err)
+    }
+}
+
+func TestMenuChoiceArgs(T *testing.T) {
+    var args string
+    s := NewSession(strings.NewReader("say don't panic\n"), new(bytes.Buffer))
+    s.Choose(func(m *Menu) {
+        m.Shell = true
+        m.Choice("say", func(name, a string) { args = a })
+        m.Command("deploy", func(argv []string) error { return nil })
+    })
+    if args != "don't panic" {
+        T.Errorf("Unexpected args %#v", args)
+    }
+}
+
+func TestCommandUsage(T *testing.T) {
+    fs := flag.NewFlagSet("deploy", flag.ContinueOnError)
+    fs.String("env", "staging", "target `environment`")
+    fs.Bool("force", false, "skip checks")
+    fs.Int("n", 1, "replicas")
+    expect := "deploy [-env environment] [-force] [-n int] APP [VERSION]"
+    if usage := CommandUsage("deploy", fs, "APP [VERSION]"); usage != expect {
+        T.Errorf("Unexpected usage %#v", usage)
+    }
+    for _, test := range []struct {
+        args     string
+        min, max int
+    }{
+        {"", 0, 0},
+        {"APP [VERSION]", 1, 2},
+        {"APP...", 1, -1},
+        {"SRC [DST...]", 1, -1},
+    } {
+        if min, max := argCounts(test.args); min != test.min || max != test.max {
+            T.Errorf("%#v: Unexpected counts %d %d", test.args, min, max)
+        }
+    }
+}
+
+func TestMenuCommandFlags(T *testing.T) {
+    var runs []string
+    out := new(bytes.Buffer)
+    input := "deploy -env=prod --force app1\n" +
+        "deploy -bogus app1\n" +
+        "deploy\n" +
+        "deploy -h\n" +
+        "deploy app2 app3\n"
+    s := NewSession(strings.NewReader(input), out)
+    for i := 0; i < 2; i++ {
+        s.Choose(func(m *Menu) {
+            m.Shell = true
+            fs := flag.NewFlagSet("deploy", flag.ExitOnError)
+            env := fs.String("env", "staging", "target environment")
+            force := fs.Bool("force", false, "skip checks")
+            m.CommandFlags("deploy", fs, "APP...", func(fs *flag.FlagSet) error {
+                runs = append(runs, fmt.Sprintf("%s %v %v", *env, *force, fs.Args()))
+                return nil
+            })
+        })
+    }
+    expect := []string{"prod true [app1]", "staging false [app2 app3]"}
+    if !reflect.DeepEqual(runs, expect) {
+        T.Errorf("Unexpected runs %#v", runs)
+    }
+    for _, msg := range []string{
+        "Error: flag provided but not defined: -bogus\nusage: deploy [-env string] [-force] APP...\n",
+        "Error: Too few arguments\n",
+        "\nusage: deploy [-env string] [-force] APP...\n  -env string",
+    } {
+        if !strings.Contains(out.String(), msg) {
+            T.Errorf("%#v not printed %#v", msg, out.String())
+        }
+    }
+}
+
+func TestMenuCommandFlagsReset(T *testing.T) {
+    var tags []string
+    s := NewSession(strings.NewReader("deploy -tag=v1\ndeploy app\n"), new(bytes.Buffer))
+    _, _, err := s.ChooseContext(context.Background(), func(m *Menu) {
+        m.Shell = true
+        fs := flag.NewFlagSet("deploy", flag.ContinueOnError)
+        fs.Func("tag", "release tag", func(tag string) error {
+            if tag == "" {
+                return errors.New("empty tag")
+            }
+            tags = append(tags, tag)
+            return nil
+        })
+        m.CommandFlags("deploy", fs, "APP", func(fs *flag.FlagSet) error { return nil })
+    })
+    if err == nil || !strings.Contains(err.Error(), "-tag: empty tag") {
+        T.Errorf("Unexpected error %v", err)
+    }
+    if fmt.Sprint(tags) != "[v1]" {
+        T.Errorf("Unexpected tags %v", tags)
+    }
+}
+
+func TestMenuCommandFlagsIndex(T *testing.T) {
+    var argv0 string
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("0 -bogus\n1\n"), out)
+    s.Choose(func(m *Menu) {
+        m.Shell = true
+        fs := flag.NewFlagSet("deploy", flag.ExitOnError)
+        m.CommandFlags("deploy", fs, "[APP]", func(fs *flag.FlagSet) error { return nil })
+        m.Command("status", func(argv []string) error {
+            argv0 = argv[0]
+            return nil
+        })
+    })
+    if msg := "usage: deploy [APP]\n"; !strings.Contains(out.String(), msg) {
+        T.Errorf("%#v not printed %#v", msg, out.String())
+    }
+    if argv0 != "status" {
+        T.Errorf("Unexpected command name %q", argv0)
+    }
+}
@@ -0,0 +1,475 @@
+package goline
+
+/*
+ *  Filename:    editor.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:49:03 UTC 2026
+ *  Description: An interactive line editor with emacs-style key bindings.
+ */
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//  A LineEditor edits a single line of input read from a terminal in raw
+//  mode. Each key sequence typed is looked up in a Keymap and the bound
+//  EditCommand is run. Unbound printable runes are inserted at the cursor.
+//  The line is redrawn after each command.
+//
+//  Lines wider than the terminal are not handled specially, so editing them
+//  may garble the display.
+type LineEditor struct {
+	// The line being edited.
+	Buf []rune
+	// The cursor position, an index into Buf.
+	Pos int
+	// The prompt displayed before the line.
+	Prompt  string
+	s       *Session
+	q       *Question
+	col     int
+	killed  []rune
+	hist    [][]rune
+	histPos int
+	tab     bool
+	lastTab bool
+	done    bool
+	err     error
+}
+
+//  A command run when a key sequence is typed. See Keymap.
+type EditCommand func(e *LineEditor)
+
+//  A Keymap binds key sequences, as the bytes sent by the terminal, to
+//  EditCommands. For example, "\x01" is Ctrl-A and "\x1b[D" is the left
+//  arrow key.
+//      keymap := goline.DefaultKeymap.Copy()
+//      keymap["\x1bOH"] = (*goline.LineEditor).Home
+//      goline.DefaultSession.Keymap = keymap
+type Keymap map[string]EditCommand
+
+//  The default (emacs-style) key bindings.
+var DefaultKeymap = Keymap{
+	"\r":        (*LineEditor).Accept,
+	"\n":        (*LineEditor).Accept,
+	"\x03":      (*LineEditor).Interrupt,     // Ctrl-C
+	"\x04":      (*LineEditor).DeleteOrEOF,   // Ctrl-D
+	"\x01":      (*LineEditor).Home,          // Ctrl-A
+	"\x05":      (*LineEditor).End,           // Ctrl-E
+	"\x02":      (*LineEditor).Left,          // Ctrl-B
+	"\x06":      (*LineEditor).Right,         // Ctrl-F
+	"\x08":      (*LineEditor).Backspace,     // Ctrl-H
+	"\x7f":      (*LineEditor).Backspace,     // Backspace
+	"\x0b":      (*LineEditor).KillToEnd,     // Ctrl-K
+	"\x15":      (*LineEditor).KillToStart,   // Ctrl-U
+	"\x17":      (*LineEditor).KillWord,      // Ctrl-W
+	"\x19":      (*LineEditor).Yank,          // Ctrl-Y
+	"\x14":      (*LineEditor).Transpose,     // Ctrl-T
+	"\x0c":      (*LineEditor).Redraw,        // Ctrl-L
+	"\t":        (*LineEditor).Complete,      // Tab
+	"\x1bb":     (*LineEditor).WordLeft,      // Alt-B
+	"\x1bf":     (*LineEditor).WordRight,     // Alt-F
+	"\x1bd":     (*LineEditor).KillWordRight, // Alt-D
+	"\x10":      (*LineEditor).HistoryPrev,   // Ctrl-P
+	"\x0e":      (*LineEditor).HistoryNext,   // Ctrl-N
+	"\x1b[A":    (*LineEditor).HistoryPrev,
+	"\x1b[B":    (*LineEditor).HistoryNext,
+	"\x1bOA":    (*LineEditor).HistoryPrev,
+	"\x1bOB":    (*LineEditor).HistoryNext,
+	"\x1b[D":    (*LineEditor).Left,
+	"\x1b[C":    (*LineEditor).Right,
+	"\x1bOD":    (*LineEditor).Left,
+	"\x1bOC":    (*LineEditor).Right,
+	"\x1b[H":    (*LineEditor).Home,
+	"\x1b[F":    (*LineEditor).End,
+	"\x1bOH":    (*LineEditor).Home,
+	"\x1bOF":    (*LineEditor).End,
+	"\x1b[1~":   (*LineEditor).Home,
+	"\x1b[4~":   (*LineEditor).End,
+	"\x1b[3~":   (*LineEditor).Delete,
+	"\x1b[1;5D": (*LineEditor).WordLeft,  // Ctrl-Left
+	"\x1b[1;5C": (*LineEditor).WordRight, // Ctrl-Right
+}
+
+//  Returns a copy of the Keymap that can be modified without affecting km.
+func (km Keymap) Copy() Keymap {
+	cp := make(Keymap, len(km))
+	for k, cmd := range km {
+		cp[k] = cmd
+	}
+	return cp
+}
+
+//  Returns true if seq is a proper prefix of a key sequence in km.
+func (km Keymap) hasPrefix(seq string) bool {
+	for k := range km {
+		if len(k) > len(seq) && strings.HasPrefix(k, seq) {
+			return true
+		}
+	}
+	return false
+}
+
+//  Finish editing, accepting the line.
+func (e *LineEditor) Accept() { e.done = true }
+
+//  Finish editing with the error ErrorInterrupt.
+func (e *LineEditor) Interrupt() { e.Abort(ErrorInterrupt) }
+
+//  Finish editing with the error err.
+func (e *LineEditor) Abort(err error) {
+	e.done = true
+	e.err = err
+}
+
+//  Delete the rune under the cursor, or finish editing with io.EOF if the
+//  line is empty.
+func (e *LineEditor) DeleteOrEOF() {
+	if len(e.Buf) == 0 {
+		e.Abort(io.EOF)
+		return
+	}
+	e.Delete()
+}
+
+//  Move the cursor to the start of the line.
+func (e *LineEditor) Home() { e.Pos = 0 }
+
+//  Move the cursor to the end of the line.
+func (e *LineEditor) End() { e.Pos = len(e.Buf) }
+
+//  Move the cursor one rune left.
+func (e *LineEditor) Left() {
+	if e.Pos > 0 {
+		e.Pos--
+	}
+}
+
+//  Move the cursor one rune right.
+func (e *LineEditor) Right() {
+	if e.Pos < len(e.Buf) {
+		e.Pos++
+	}
+}
+
+func isWordRune(c rune) bool { return unicode.IsLetter(c) || unicode.IsDigit(c) }
+
+//  The start of the word before the cursor.
+func (e *LineEditor) wordStart(inWord func(rune) bool) int {
+	i := e.Pos
+	for i > 0 && !inWord(e.Buf[i-1]) {
+		i--
+	}
+	for i > 0 && inWord(e.Buf[i-1]) {
+		i--
+	}
+	return i
+}
+
+//  The end of the word after the cursor.
+func (e *LineEditor) wordEnd(inWord func(rune) bool) int {
+	i := e.Pos
+	for i < len(e.Buf) && !inWord(e.Buf[i]) {
+		i++
+	}
+	for i < len(e.Buf) && inWord(e.Buf[i]) {
+		i++
+	}
+	return i
+}
+
+//  Move the cursor to the start of the previous word.
+func (e *LineEditor) WordLeft() { e.Pos = e.wordStart(isWordRune) }
+
+//  Move the cursor to the end of the next word.
+func (e *LineEditor) WordRight() { e.Pos = e.wordEnd(isWordRune) }
+
+//  Insert runes at the cursor.
+func (e *LineEditor) Insert(rs ...rune) {
+	buf := make([]rune, 0, len(e.Buf)+len(rs))
+	buf = append(append(append(buf, e.Buf[:e.Pos]...), rs...), e.Buf[e.Pos:]...)
+	e.Buf = buf
+	e.Pos += len(rs)
+}
+
+//  Delete the runes in [i, j) and return them.
+func (e *LineEditor) cut(i, j int) []rune {
+	cut := append([]rune(nil), e.Buf[i:j]...)
+	e.Buf = append(e.Buf[:i], e.Buf[j:]...)
+	switch {
+	case e.Pos >= j:
+		e.Pos -= j - i
+	case e.Pos > i:
+		e.Pos = i
+	}
+	return cut
+}
+
+//  Delete the rune before the cursor.
+func (e *LineEditor) Backspace() {
+	if e.Pos > 0 {
+		e.cut(e.Pos-1, e.Pos)
+	}
+}
+
+//  Delete the rune under the cursor.
+func (e *LineEditor) Delete() {
+	if e.Pos < len(e.Buf) {
+		e.cut(e.Pos, e.Pos+1)
+	}
+}
+
+//  Delete from the cursor to the end of the line. The deleted text can be
+//  inserted with Yank.
+func (e *LineEditor) KillToEnd() { e.killed = e.cut(e.Pos, len(e.Buf)) }
+
+//  Delete from the start of the line to the cursor. The deleted text can be
+//  inserted with Yank.
+func (e *LineEditor) KillToStart() { e.killed = e.cut(0, e.Pos) }
+
+//  Delete the whitespace delimited word before the cursor. The deleted text
+//  can be inserted with Yank.
+func (e *LineEditor) KillWord() {
+	notSpace := func(c rune) bool { return !unicode.IsSpace(c) }
+	e.killed = e.cut(e.wordStart(notSpace), e.Pos)
+}
+
+//  Delete the word after the cursor. The deleted text can be inserted with
+//  Yank.
+func (e *LineEditor) KillWordRight() { e.killed = e.cut(e.Pos, e.wordEnd(isWordRune)) }
+
+//  Insert the text most recently deleted by a Kill command.
+func (e *LineEditor) Yank() { e.Insert(e.killed...) }
+
+//  Swap the rune before the cursor with the rune under it, and move the
+//  cursor right. At the end of the line the last two runes are swapped.
+func (e *LineEditor) Transpose() {
+	if e.Pos == 0 || len(e.Buf) < 2 {
+		return
+	}
+	if e.Pos == len(e.Buf) {
+		e.Pos--
+	}
+	e.Buf[e.Pos-1], e.Buf[e.Pos] = e.Buf[e.Pos], e.Buf[e.Pos-1]
+	e.Pos++
+}
+
+//  Replace the line with the previous entry in the Session's History. The
+//  line being edited is kept, and can be returned to with HistoryNext.
+func (e *LineEditor) HistoryPrev() { e.historyMove(-1) }
+
+//  Replace the line with the next entry in the Session's History.
+func (e *LineEditor) HistoryNext() { e.historyMove(1) }
+
+func (e *LineEditor) historyMove(d int) {
+	i := e.histPos + d
+	if i < 0 || i >= len(e.hist) {
+		return
+	}
+	// Edits to recalled entries are kept until the line is accepted.
+	e.hist[e.histPos] = e.Buf
+	e.histPos = i
+	e.Buf = append([]rune(nil), e.hist[i]...)
+	e.Pos = len(e.Buf)
+}
+
+//  Clear the screen and redraw the prompt and line.
+func (e *LineEditor) Redraw() {
+	fmt.Fprint(e.s.Out, "\x1b[H\x1b[2J")
+	e.s.Say(e.Prompt)
+	e.col = 0
+}
+
+//  The start of the answer, or of the slice element, before the cursor.
+func (e *LineEditor) answerStart() int {
+	if e.q == nil || !e.q.typ.IsSliceType() {
+		return 0
+	}
+	i := e.Pos
+	if e.q.Sep == "" {
+		for i > 0 && !unicode.IsSpace(e.Buf[i-1]) {
+			i--
+		}
+		return i
+	}
+	sep := []rune(e.q.Sep)
+	for ; i >= len(sep); i-- {
+		if string(e.Buf[i-len(sep):i]) == e.q.Sep {
+			break
+		}
+	}
+	if i < len(sep) {
+		return 0
+	}
+	return i
+}
+
+//  Returns the completions of prefix in the Question's AnswerSet. Sets that
+//  are not CandidateSets can only complete a prefix to a single answer.
+func (e *LineEditor) candidates(prefix string) (cands []string) {
+	if e.q == nil || e.q.typ.Elem() != String {
+		return nil
+	}
+	switch set := e.q.set.(type) {
+	case CandidateSet:
+		return set.Candidates(prefix)
+	case CompletionSet:
+		defer func() {
+			if recover() != nil {
+				cands = nil
+			}
+		}()
+		if x, err := set.Complete(prefix); err == nil {
+			if str, ok := x.(string); ok {
+				return []string{str}
+			}
+		}
+	}
+	return nil
+}
+
+//  The longest prefix shared by all strs.
+func commonPrefix(strs []string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	prefix := strs[0]
+	for _, str := range strs[1:] {
+		i := 0
+		for i < len(prefix) && i < len(str) && prefix[i] == str[i] {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix
+}
+
+//  Complete the answer before the cursor if the Question's AnswerSet is a
+//  CompletionSet. The answer is extended to the longest prefix shared by
+//  its completions (a lone completion replaces it). When Complete is run
+//  twice in a row the completions are listed below the line. The elements
+//  of slice answers are completed individually.
+func (e *LineEditor) Complete() {
+	e.tab = true
+	start := e.answerStart()
+	prefix := string(e.Buf[start:e.Pos])
+	cands := e.candidates(prefix)
+	if len(cands) == 0 {
+		return
+	}
+	common := commonPrefix(cands)
+	if len(cands) == 1 || len(common) > len(prefix) && strings.HasPrefix(common, prefix) {
+		e.cut(start, e.Pos)
+		e.Insert([]rune(common)...)
+		return
+	}
+	if e.lastTab {
+		e.s.Say("")
+		fList(e.s.writer(), cands, ColumnsDownPacked, nil, e.s.textWidth())
+		e.s.Say(e.Prompt)
+		e.col = 0
+	}
+}
+
+//  Redraw the line and place the cursor. Only the part of the terminal after
+//  the prompt is changed.
+func (e *LineEditor) refresh() {
+	var b strings.Builder
+	if e.col > 0 {
+		fmt.Fprintf(&b, "\x1b[%dD", e.col)
+	}
+	b.WriteString(string(e.Buf))
+	b.WriteString("\x1b[K")
+	if back := runesWidth(e.Buf[e.Pos:]); back > 0 {
+		fmt.Fprintf(&b, "\x1b[%dD", back)
+	}
+	e.col = runesWidth(e.Buf[:e.Pos])
+	io.WriteString(e.s.Out, b.String())
+}
+
+//  Edit a line of input. The terminal must already be in raw mode and the
+//  prompt must already have been displayed.
+func (s *Session) editLine(ctx context.Context, q *Question, prompt string) ([]rune, error) {
+	keymap := s.Keymap
+	if keymap == nil {
+		keymap = DefaultKeymap
+	}
+	e := &LineEditor{Prompt: prompt, s: s, q: q}
+	for _, entry := range s.recall(q) {
+		e.hist = append(e.hist, []rune(entry))
+	}
+	e.hist = append(e.hist, nil)
+	e.histPos = len(e.hist) - 1
+	var seq, unread []byte
+	for !e.done {
+		var c byte
+		if len(unread) > 0 {
+			c, unread = unread[0], unread[1:]
+		} else if b, err := s.readByte(ctx); err != nil {
+			return nil, err
+		} else {
+			c = b
+		}
+		seq = append(seq, c)
+		if cmd, ok := keymap[string(seq)]; ok {
+			seq = seq[:0]
+			e.lastTab, e.tab = e.tab, false
+			cmd(e)
+		} else if keymap.hasPrefix(string(seq)) {
+			continue
+		} else if seq[0] == 0x1b {
+			// Discard unbound escape sequences. Control sequences end with a
+			// byte in the range 0x40-0x7E.
+			isCSI := len(seq) > 1 && (seq[1] == '[' || seq[1] == 'O')
+			if isCSI && (len(seq) == 2 || c < 0x40 || c > 0x7e) {
+				continue
+			}
+			if len(seq) == 2 && !isCSI {
+				// A lone Esc is dropped but the key typed after it is not.
+				unread = append(unread, c)
+			}
+			seq = seq[:0]
+		} else if seq[0] >= ' ' && seq[0] != 0x7f {
+			if !utf8.FullRune(seq) {
+				continue
+			}
+			r, _ := utf8.DecodeRune(seq)
+			seq = seq[:0]
+			e.tab = false
+			e.Insert(r)
+		} else {
+			seq = seq[:0]
+		}
+		if !e.done {
+			e.refresh()
+		}
+	}
+	if e.err == nil {
+		e.End()
+		e.refresh()
+	}
+	s.Say("")
+	return e.Buf, e.err
+}
+
+//  Read a line of input for q from the terminal fd using the line editor.
+func (s *Session) readEdited(ctx context.Context, fd uintptr, q *Question, prompt string) ([]byte, error) {
+	restore, err := makeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	line, err := s.editLine(ctx, q, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(string(line)), nil
+}
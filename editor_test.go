@@ -0,0 +1,104 @@
+package goline
+/*
+ *  Filename:    editor_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:49:03 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "io"
+    "strings"
+    "testing"
+)
+
+func testEdit(T *testing.T, name, keys, expect string) {
+    s := NewSession(strings.NewReader(keys), new(bytes.Buffer))
+    line, err := s.editLine(context.Background(), nil, "? ")
+    if err != nil {
+        T.Errorf("%s: Unexpected error %v", name, err)
+    } else if string(line) != expect {
+        T.Errorf("%s: Unexpected line %#v != %#v", name, string(line), expect)
+    }
+}
+
+func TestLineEditor(T *testing.T) {
+    testEdit(T, "Insert", "hello\r", "hello")
+    testEdit(T, "UTF-8", "h\xc3\xa9llo\x1b[D\x1b[D\x7f\r", "hélo")
+    testEdit(T, "Home", "world\x01hello \r", "hello world")
+    testEdit(T, "End", "ello\x01h\x05!\r", "hello!")
+    testEdit(T, "Arrows", "ac\x1b[Db\x1b[C!\r", "abc!")
+    testEdit(T, "Kill word", "rm -rf /\x17tmp\r", "rm -rf tmp")
+    testEdit(T, "Kill and yank", "hello world\x01\x1bd\x05 \x19\x01\x1b[3~\r", "world hello")
+    testEdit(T, "Kill to end", "hello world\x1bb\x0b\r", "hello ")
+    testEdit(T, "Kill to start", "abc\x1b[Dx\x15\r", "c")
+    testEdit(T, "Transpose", "teh\x1b[D\x14\r", "the")
+    testEdit(T, "Delete", "abc\x01\x1b[3~\x04\r", "c")
+    testEdit(T, "Word movement", "one two three\x1bb\x1bb\x1bd\r", "one  three")
+    testEdit(T, "Unbound escape", "ab\x1b[2~c\r", "abc")
+    testEdit(T, "Lone escape", "ab\x1bc\x1b\x1be\x1b\r", "abce")
+}
+
+func TestLineEditorErrors(T *testing.T) {
+    s := NewSession(strings.NewReader("\x04"), new(bytes.Buffer))
+    if _, err := s.editLine(context.Background(), nil, "? "); err != io.EOF {
+        T.Errorf("Unexpected error %v", err)
+    }
+    s = NewSession(strings.NewReader("abc\x03"), new(bytes.Buffer))
+    if _, err := s.editLine(context.Background(), nil, "? "); err != ErrorInterrupt {
+        T.Errorf("Unexpected error %v", err)
+    }
+}
+
+func TestLineEditorDisplay(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("ab\x1b[Dc\r"), out)
+    s.editLine(context.Background(), nil, "? ")
+    expect := "a\x1b[K" + "\x1b[1Dab\x1b[K" + "\x1b[2Dab\x1b[K\x1b[1D" +
+        "\x1b[1Dacb\x1b[K\x1b[1D" + "\x1b[2Dacb\x1b[K" + "\n"
+    if out.String() != expect {
+        T.Errorf("Unexpected output %#v != %#v", out.String(), expect)
+    }
+}
+
+func TestLineEditorComplete(T *testing.T) {
+    fruit := StringCompletionSet{"apple", "apricot", "banana"}
+    testComplete := func(name string, q *Question, keys, expect string) {
+        s := NewSession(strings.NewReader(keys), new(bytes.Buffer))
+        line, err := s.editLine(context.Background(), q, "? ")
+        if err != nil {
+            T.Errorf("%s: Unexpected error %v", name, err)
+        } else if string(line) != expect {
+            T.Errorf("%s: Unexpected line %#v != %#v", name, string(line), expect)
+        }
+    }
+    q := newQuestion(String)
+    q.In(fruit)
+    testComplete("Unique", q, "b\t\r", "banana")
+    testComplete("Common prefix", q, "a\tr\t\r", "apricot")
+    testComplete("No completion", q, "c\t\r", "c")
+    q = newQuestion(String)
+    q.In(shellCommandSet{"echo", "exit", "ls"})
+    testComplete("Shell", q, "ec\t hello\r", "echo hello")
+    testComplete("Shell arguments", q, "ls e\t\r", "ls e")
+    q = newQuestion(StringSlice)
+    q.Sep = ","
+    q.In(fruit)
+    testComplete("Slice", q, "apple,b\t\r", "apple,banana")
+    q = newQuestion(String)
+    q.In(StringSet{"apple"})
+    testComplete("Not a CompletionSet", q, "a\t\r", "a")
+}
+
+func TestLineEditorListCompletions(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("a\t\t\r"), out)
+    q := newQuestion(String)
+    q.In(StringCompletionSet{"apple", "apricot", "banana"})
+    s.editLine(context.Background(), q, "? ")
+    if !strings.Contains(out.String(), "\napple  apricot\n? ap") {
+        T.Errorf("Completions not listed %#v", out.String())
+    }
+}
@@ -17,6 +17,9 @@ import (
 var (
 	ErrorEmptyInput = NewErrorRecoverable("Can not use empty string as value")
 	ErrorNoChoices  = NewError("No Menu choices given")
+	ErrorInterrupt  = NewError("Interrupted")
+	// Returned by SplitWords when a quote is not closed.
+	ErrorUnterminatedQuote = NewErrorRecoverable("Unterminated quote")
 )
 
 //  An interface for errors which prompts can recover from.
@@ -71,13 +74,22 @@ func (e ErrorPrecision) IsRecoverable() bool { return true }
 func (e ErrorPrecision) Response() Response  { return Precision }
 
 //  Errors returned when the input provided was not in a Question's AnswerSet.
-type ErrorNotInSet struct{ error }
+//  Suggestions holds similar members of an EnumerableSet.
+type ErrorNotInSet struct {
+	error
+	Suggestions []string
+}
 
-func (a *Question) makeErrorNotInSet(val interface{}) ErrorNotInSet {
-	if msg := a.Responses[NotInSet]; msg != "" {
-		return ErrorNotInSet{fmt.Errorf("%s %v (%#v)", msg, a.set, val)}
+func (a *Question) makeErrorNotInSet(set Stringer, val interface{}) ErrorNotInSet {
+	msg := a.Responses[NotInSet]
+	if msg == "" {
+		return ErrorNotInSet{errors.New("Not in set"), nil}
 	}
-	return ErrorNotInSet{errors.New("Not in set")}
+	if sugg := suggestions(set, val); len(sugg) > 0 {
+		hint := didYouMean(a.Responses[DidYouMean], sugg)
+		return ErrorNotInSet{fmt.Errorf("%s %#v. %s", msg, val, hint), sugg}
+	}
+	return ErrorNotInSet{fmt.Errorf("%s %v (%#v)", msg, set, val), nil}
 }
 func (err ErrorNotInSet) IsRecoverable() bool { return true }
 func (err ErrorNotInSet) Response() Response  { return NotInSet }
@@ -103,6 +115,20 @@ func (e ErrorType) Error() string {
 }
 func (e ErrorType) Response() Response { return InvalidType }
 
+//  Errors raised when the input could not be parsed as a Custom type.
+type ErrorParse struct {
+	Type  string
+	Input string
+	Err   error
+}
+
+func (e ErrorParse) IsRecoverable() bool { return true }
+func (e ErrorParse) Unwrap() error       { return e.Err }
+func (e ErrorParse) Error() string {
+	return fmt.Sprintf("Invalid %s %#v (%v)", e.Type, e.Input, e.Err)
+}
+func (e ErrorParse) Response() Response { return InvalidType }
+
 //  Errors raised when an AnswerSet of improper type was given to the Question.
 type ErrorMemberType struct{ Set, Member reflect.Type }
 
@@ -115,17 +141,25 @@ func (err ErrorMemberType) Error() string {
 	return fmt.Sprintf("%s can't contain %s", err.Type(), err.MemberType())
 }
 
+//  Errors returned when an answer completes to no member of a CompletionSet.
+//  When Suggestions (similar members, see Suggest) are given they are
+//  offered in place of listing Set, introduced by DidYouMean.
 type ErrorNoCompletion struct {
-	Msg   string
-	Set   AnswerSet
-	Value interface{}
+	Msg         string
+	Set         AnswerSet
+	Value       interface{}
+	Suggestions []string
+	DidYouMean  string
 }
 
 func makeErrorNoCompletion(set AnswerSet, value interface{}) error {
-	return ErrorNoCompletion{defaultResponses[NoCompletion], set, value}
+	return ErrorNoCompletion{Msg: defaultResponses[NoCompletion], Set: set, Value: value}
 }
 func (err ErrorNoCompletion) IsRecoverable() bool { return true }
 func (err ErrorNoCompletion) Error() string {
+	if len(err.Suggestions) > 0 {
+		return fmt.Sprintf("%v %#v. %s", err.Msg, err.Value, didYouMean(err.DidYouMean, err.Suggestions))
+	}
 	return fmt.Sprintf("%v (%v in %v)", err.Msg, err.Value, err.Set)
 }
 
@@ -136,9 +170,27 @@ type ErrorAmbiguousCompletion struct {
 }
 
 func makeErrorAmbiguousCompletion(set AnswerSet, value interface{}) error {
-	return ErrorNoCompletion{defaultResponses[AmbiguousCompletion], set, value}
+	return ErrorAmbiguousCompletion{defaultResponses[AmbiguousCompletion], set, value}
 }
 func (err ErrorAmbiguousCompletion) IsRecoverable() bool { return true }
 func (err ErrorAmbiguousCompletion) Error() string {
 	return fmt.Sprintf("%s (%v in %v)", err.Msg, err.Value, err.Set)
 }
+
+//  Errors returned by commands added with Menu.CommandFlags when they are
+//  given invalid flags or arguments. Usage describes the command's syntax.
+type ErrorUsage struct {
+	Err   error
+	Usage string
+}
+
+func (err ErrorUsage) IsRecoverable() bool { return true }
+func (err ErrorUsage) Unwrap() error       { return err.Err }
+func (err ErrorUsage) Error() string       { return err.Err.Error() + "\n" + err.Usage }
+
+//  Errors returned when a prompt is abandoned because its context was
+//  canceled or its deadline passed. Err is the context's error.
+type ErrorCanceled struct{ Err error }
+
+func (err ErrorCanceled) Error() string { return fmt.Sprintf("Prompt abandoned (%v)", err.Err) }
+func (err ErrorCanceled) Unwrap() error { return err.Err }
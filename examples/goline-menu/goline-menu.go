@@ -14,6 +14,7 @@ package main
 import (
 	"fmt"
 	"goline"
+	"strings"
 )
 
 var opt = parseFlags()
@@ -41,16 +42,15 @@ func main() {
 	})
 	fmt.Println("Selected", i)
 	fmt.Println()
-	for cont := true; cont; {
-		goline.Choose(func(m *goline.Menu) {
-			m.Header = "Enter a command: "
-			m.Question = "?> "
-			m.Shell = true
-			m.ListMode = goline.Inline
-			m.IndexMode = goline.NoIndex
-			m.Choice("echo", func(s string, args string) { fmt.Println(s, "|", args) })
-			m.Choice("quit", func(s string, args string) { cont = false })
-			m.Choice("exit", func(s string, args string) { cont = false })
+	goline.REPL(func(m *goline.Menu) {
+		m.Header = "Enter a command: "
+		m.Question = "?> "
+		m.ListMode = goline.Inline
+		m.IndexMode = goline.NoIndex
+		m.Command("echo", func(argv []string) error {
+			fmt.Println(strings.Join(argv[1:], " "))
+			return nil
 		})
-	}
+		m.Describe("echo", "print the arguments")
+	})
 }
@@ -0,0 +1,197 @@
+package goline
+
+/*
+ *  Filename:    filter.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:07:04 UTC 2026
+ *  Description: Menus narrowed by a fuzzy query as the user types.
+ */
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//  The state of a Filter menu.
+type menuFilter struct {
+	s       *Session
+	m       *Menu
+	names   []string
+	items   []string
+	query   []rune
+	matches []fuzzyResult
+	cur     int
+	top     int
+	rows    int
+	colors  bool
+}
+
+//  Let the user choose from items (the rendered choices of m) by typing a
+//  fuzzy query. The terminal fd is put in raw mode.
+func (s *Session) filterChoice(ctx context.Context, fd uintptr, m *Menu, items []string) (int, error) {
+	restore, err := makeRaw(fd)
+	if err != nil {
+		return -1, err
+	}
+	defer restore()
+	return s.runFilter(ctx, m, items)
+}
+
+//  Run a Filter menu on a terminal already in raw mode.
+func (s *Session) runFilter(ctx context.Context, m *Menu, items []string) (int, error) {
+	if len(m.Header) > 0 {
+		s.Say(m.Header)
+	}
+	f := &menuFilter{s: s, m: m, items: items, rows: s.viewport(m), colors: s.colorEnabled()}
+	for _, c := range m.Choices {
+		f.names = append(f.names, c.String())
+	}
+	f.match()
+	f.draw()
+	for {
+		key, err := s.readKey(ctx)
+		if err != nil {
+			io.WriteString(s.Out, "\r\x1b[J")
+			return -1, err
+		}
+		switch key {
+		case "\r", "\n":
+			if len(f.matches) == 0 {
+				continue
+			}
+			i := f.matches[f.cur].index
+			io.WriteString(s.Out, "\r\x1b[J")
+			s.Say(m.Question + EscapeMarkup(f.names[i]) + "\n")
+			return i, nil
+		case "\x03":
+			io.WriteString(s.Out, "\r\x1b[J")
+			return -1, ErrorInterrupt
+		case "\x04":
+			io.WriteString(s.Out, "\r\x1b[J")
+			return -1, io.EOF
+		case "\x1b[A", "\x1bOA", "\x10", "\x1b[Z":
+			f.move(f.cur - 1)
+		case "\x1b[B", "\x1bOB", "\x0e", "\t":
+			f.move(f.cur + 1)
+		case "\x7f", "\x08":
+			if len(f.query) > 0 {
+				f.query = f.query[:len(f.query)-1]
+				f.match()
+			}
+		case "\x15": // Ctrl-U
+			f.query = nil
+			f.match()
+		case "\x17": // Ctrl-W
+			i := len(f.query)
+			for i > 0 && unicode.IsSpace(f.query[i-1]) {
+				i--
+			}
+			for i > 0 && !unicode.IsSpace(f.query[i-1]) {
+				i--
+			}
+			f.query = f.query[:i]
+			f.match()
+		default:
+			c, _ := utf8.DecodeRuneInString(key)
+			if utf8.RuneCountInString(key) != 1 || !unicode.IsPrint(c) {
+				continue
+			}
+			f.query = append(f.query, c)
+			f.match()
+		}
+		f.draw()
+	}
+}
+
+//  Match the query against the choice names and highlight the best match.
+//  All choices match an empty query, in their original order.
+func (f *menuFilter) match() {
+	if len(f.query) == 0 {
+		f.matches = make([]fuzzyResult, len(f.names))
+		for i := range f.names {
+			f.matches[i].index = i
+		}
+	} else {
+		f.matches = fuzzyRank(string(f.query), f.names)
+	}
+	f.cur, f.top = 0, 0
+}
+
+//  Highlight the match at index i, scrolling the viewport to show it.
+func (f *menuFilter) move(i int) {
+	if i >= len(f.matches) {
+		i = len(f.matches) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	f.cur = i
+	if i < f.top {
+		f.top = i
+	} else if i >= f.top+f.rows {
+		f.top = i - f.rows + 1
+	}
+}
+
+//  Redraw the query line and the matches below it. The cursor is left at the
+//  end of the query.
+func (f *menuFilter) draw() {
+	var b strings.Builder
+	prompt := f.s.indentation() + renderMarkup(f.m.Question, f.colors) + string(f.query)
+	b.WriteString("\r" + prompt + "\x1b[K")
+	width := f.s.textWidth()
+	blank := strings.Repeat(" ", StringWidth(SelectMarker))
+	n := 0
+	for j := f.top; j < len(f.matches) && j < f.top+f.rows; j++ {
+		r := f.matches[j]
+		marker := blank
+		if j == f.cur {
+			marker = SelectMarker
+		}
+		// Matched positions are offset by the marker and the choice index.
+		offset := utf8.RuneCountInString(marker + f.m.getIndex(r.index))
+		line := f.highlight(marker+f.items[r.index], width-1, offset, r.positions)
+		if j == f.cur && f.colors {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+		b.WriteString("\n" + f.s.indentation() + line + "\x1b[K")
+		n++
+	}
+	b.WriteString("\x1b[J")
+	if n > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA\r%s", n, prompt)
+	}
+	io.WriteString(f.s.Out, b.String())
+}
+
+//  Truncate line to width w and, when colors are enabled, embolden and
+//  underline the runes at the given positions (offset by offset).
+func (f *menuFilter) highlight(line string, w, offset int, positions []int) string {
+	plain := truncate(line, w, "…")
+	if !f.colors || len(positions) == 0 {
+		return plain
+	}
+	rs := []rune(plain)
+	end := len(rs)
+	if plain != line {
+		// Don't highlight the ellipsis.
+		end--
+	}
+	on := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		on[p+offset] = true
+	}
+	var b strings.Builder
+	for i, c := range rs {
+		if i < end && on[i] {
+			b.WriteString("\x1b[1;4m" + string(c) + "\x1b[22;24m")
+		} else {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
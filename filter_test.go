@@ -0,0 +1,88 @@
+package goline
+/*
+ *  Filename:    filter_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:07:04 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "io"
+    "strings"
+    "testing"
+)
+
+func testFilter(keys string) (int, string, error) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(keys), out)
+    m := newMenu()
+    m.Question = "Namespace? "
+    m.Viewport = 3
+    m.Filter = true
+    for _, c := range []string{"default", "kube-public", "kube-system", "monitoring"} {
+        m.Choice(c, nil)
+    }
+    items, _, _ := m.Selections()
+    i, err := s.runFilter(context.Background(), m, items)
+    return i, out.String(), err
+}
+
+func TestFilterChoice(T *testing.T) {
+    for _, test := range []struct {
+        keys   string
+        expect int
+    }{
+        {"\r", 0},
+        {"kbsys\r", 2},
+        {"kube\x1b[B\r", 2},
+        {"ul\r", 0},
+        {"ul\t\r", 1},
+        {"xyz\r\x7f\x7f\x7fmon\r", 3},
+        {"kube pub\x17\x17sys\r", 2},
+        {"xyz\x15\x1b[B\r", 1},
+    } {
+        if i, _, err := testFilter(test.keys); err != nil || i != test.expect {
+            T.Errorf("%#v: Unexpected choice %d %v", test.keys, i, err)
+        }
+    }
+    if _, _, err := testFilter("kb\x03"); err != ErrorInterrupt {
+        T.Errorf("Unexpected error %v", err)
+    }
+    if _, _, err := testFilter("kb"); err != io.EOF {
+        T.Errorf("Unexpected error %v", err)
+    }
+}
+
+func TestFilterDraw(T *testing.T) {
+    i, out, err := testFilter("mo\r")
+    if err != nil || i != 3 {
+        T.Fatalf("Unexpected choice %d %v", i, err)
+    }
+    expect := "\rNamespace? \x1b[K" +
+        "\n> 0. default\x1b[K\n  1. kube-public\x1b[K\n  2. kube-system\x1b[K" +
+        "\x1b[J\x1b[3A\rNamespace? " +
+        "\rNamespace? m\x1b[K" +
+        "\n> 3. monitoring\x1b[K\n  2. kube-system\x1b[K" +
+        "\x1b[J\x1b[2A\rNamespace? m" +
+        "\rNamespace? mo\x1b[K" +
+        "\n> 3. monitoring\x1b[K" +
+        "\x1b[J\x1b[1A\rNamespace? mo" +
+        "\r\x1b[JNamespace? monitoring\n"
+    if out != expect {
+        T.Errorf("Unexpected output %#v != %#v", out, expect)
+    }
+}
+
+func TestFilterFallback(T *testing.T) {
+    s := NewSession(strings.NewReader("kbsys\n"), new(bytes.Buffer))
+    i, _ := s.Choose(func(m *Menu) {
+        m.Filter = true
+        m.Choice("kube-public", nil)
+        m.Choice("kube-system", nil)
+    })
+    if i != 1 {
+        T.Errorf("Unexpected choice %d", i)
+    }
+}
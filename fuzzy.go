@@ -0,0 +1,128 @@
+package goline
+
+/*
+ *  Filename:    fuzzy.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:07:04 UTC 2026
+ *  Description: Fuzzy (subsequence) matching of answers.
+ */
+import (
+	"sort"
+	"unicode"
+)
+
+//  Match pattern against str. The pattern matches if its runes appear in
+//  str in order, ignoring case (e.g. "kbs" matches "kube-system"). Higher
+//  scores are given to matches at the start of str and of its words, and to
+//  runs of consecutive runes. The positions of the matched runes in str
+//  (indices into []rune(str)) are returned.
+func FuzzyMatch(pattern, str string) (score int, positions []int, ok bool) {
+	rs := []rune(str)
+	j := 0
+	prev := -1
+	for _, p := range pattern {
+		p = unicode.ToLower(p)
+		for j < len(rs) && unicode.ToLower(rs[j]) != p {
+			j++
+		}
+		if j == len(rs) {
+			return 0, nil, false
+		}
+		score++
+		switch {
+		case j == 0:
+			score += 8
+		case isWordStart(rs, j):
+			score += 6
+		}
+		if prev >= 0 {
+			if j == prev+1 {
+				score += 4
+			} else if gap := j - prev - 1; gap > 3 {
+				score -= 3
+			} else {
+				score -= gap
+			}
+		}
+		positions = append(positions, j)
+		prev = j
+		j++
+	}
+	return score, positions, true
+}
+
+//  Returns true if rs[j] begins a word, following a separator or at a
+//  lower to upper case change.
+func isWordStart(rs []rune, j int) bool {
+	p, c := rs[j-1], rs[j]
+	switch {
+	case unicode.IsSpace(p), unicode.IsPunct(p), unicode.IsSymbol(p):
+		return !unicode.IsSpace(c)
+	case unicode.IsLower(p) && unicode.IsUpper(c):
+		return true
+	}
+	return false
+}
+
+//  A string matched by a fuzzy pattern.
+type fuzzyResult struct {
+	index     int
+	score     int
+	positions []int
+}
+
+//  Match pattern against each of strs. Matches are returned best first;
+//  matches with equal scores are ordered by length, then by index.
+func fuzzyRank(pattern string, strs []string) []fuzzyResult {
+	var results []fuzzyResult
+	for i, str := range strs {
+		if score, pos, ok := FuzzyMatch(pattern, str); ok {
+			results = append(results, fuzzyResult{i, score, pos})
+		}
+	}
+	sort.SliceStable(results, func(a, b int) bool {
+		ra, rb := results[a], results[b]
+		if ra.score != rb.score {
+			return ra.score > rb.score
+		}
+		return len(strs[ra.index]) < len(strs[rb.index])
+	})
+	return results
+}
+
+//  A set of strings completed by fuzzy matching (see FuzzyMatch). An answer
+//  not in the set is completed to the member it matches best, provided that
+//  no other member matches as well.
+type FuzzySet []string
+
+func (set FuzzySet) Has(x interface{}) bool { return StringSet(set).Has(x) }
+func (set FuzzySet) String() string         { return StringSet(set).String() }
+func (set FuzzySet) Members() []string      { return set }
+
+func (set FuzzySet) Complete(x interface{}) (interface{}, error) {
+	y, ok := x.(string)
+	if !ok {
+		panic(makeErrorMemberType(set, x))
+	}
+	if set.Has(y) {
+		return y, nil
+	}
+	results := fuzzyRank(y, set)
+	switch {
+	case len(results) == 0:
+		return "", makeErrorNoCompletion(set, y)
+	case len(results) > 1 && results[0].score == results[1].score:
+		return "", makeErrorAmbiguousCompletion(set, y)
+	}
+	return set[results[0].index], nil
+}
+
+//  The members of set matching pattern, best first.
+func (set FuzzySet) Candidates(pattern string) []string {
+	var cands []string
+	for _, r := range fuzzyRank(pattern, set) {
+		cands = append(cands, set[r.index])
+	}
+	return cands
+}
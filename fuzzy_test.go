@@ -0,0 +1,56 @@
+package goline
+/*
+ *  Filename:    fuzzy_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:07:04 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "reflect"
+    "testing"
+)
+
+func TestFuzzyMatch(T *testing.T) {
+    for _, test := range []struct {
+        pattern, str string
+        ok           bool
+        positions    []int
+    }{
+        {"kbs", "kube-system", true, []int{0, 2, 5}},
+        {"KS", "kube-system", true, []int{0, 5}},
+        {"prod", "aws-prod", true, []int{4, 5, 6, 7}},
+        {"sk", "kube-system", false, nil},
+        {"", "anything", true, nil},
+    } {
+        _, pos, ok := FuzzyMatch(test.pattern, test.str)
+        if ok != test.ok || !reflect.DeepEqual(pos, test.positions) {
+            T.Errorf("%q %q: Unexpected match %v %v", test.pattern, test.str, pos, ok)
+        }
+    }
+    start, _, _ := FuzzyMatch("sy", "system")
+    inner, _, _ := FuzzyMatch("sy", "easy")
+    if start <= inner {
+        T.Errorf("Match at start scored %d <= %d", start, inner)
+    }
+}
+
+func TestFuzzySet(T *testing.T) {
+    set := FuzzySet{"default", "kube-public", "kube-system", "monitoring"}
+    if v, err := set.Complete("kbsys"); err != nil || v != "kube-system" {
+        T.Errorf("Unexpected completion %v %v", v, err)
+    }
+    if v, err := set.Complete("monitoring"); err != nil || v != "monitoring" {
+        T.Errorf("Unexpected completion %v %v", v, err)
+    }
+    if _, err := set.Complete("xyz"); err == nil {
+        T.Errorf("Expected a completion error")
+    }
+    if _, err := set.Complete("kube"); err == nil {
+        T.Errorf("Expected an ambiguous completion error")
+    }
+    expect := []string{"default", "kube-public"}
+    if cands := set.Candidates("ul"); !reflect.DeepEqual(cands, expect) {
+        T.Errorf("Unexpected candidates %v", cands)
+    }
+}
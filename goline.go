@@ -29,11 +29,10 @@ See github.com/bmatsuo/goline/examples for examples using goline.
 package goline
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"reflect"
 	"strings"
 	"unicode"
@@ -57,18 +56,40 @@ func fSay(wr io.Writer, msg string, trim bool) (int, error) {
 //      goline.Say("Hello, World!") // Prints "Hello, World!\n"
 //      goline.Say("Hello, World! ") // Prints "Hello, World! "
 //  See also, SayTrimmed.
-func Say(msg string) (int, error) {
-	if c, _ := utf8.DecodeLastRuneInString(msg); unicode.IsSpace(c) {
-		return fmt.Print(msg)
-	}
-	return fmt.Println(msg)
-}
+func Say(msg string) (int, error) { return DefaultSession.Say(msg) }
+
+//  Like Say, but the message is written to s.Out.
+func (s *Session) Say(msg string) (int, error) { return s.say(msg, false) }
 
 //  Like Say, but trailing whitespace is removed from the message before
 //  an internal call to Say is made.
 //      goline.SayTrimmed("Hello, World! \n\t\t") // Prints "Hello, World!\n"
-func SayTrimmed(msg string) (int, error) {
-	return Say(strings.TrimRightFunc(msg, unicode.IsSpace))
+func SayTrimmed(msg string) (int, error) { return DefaultSession.SayTrimmed(msg) }
+
+//  Like SayTrimmed, but the message is written to s.Out.
+func (s *Session) SayTrimmed(msg string) (int, error) { return s.say(msg, true) }
+
+//  Like fSay, but style markup in msg is rendered (or removed), and msg is
+//  wrapped, indented and paged. Whether a newline is printed depends only on the
+//  text displayed.
+func (s *Session) say(msg string, trim bool) (n int, err error) {
+	if trim {
+		msg = strings.TrimRightFunc(msg, unicode.IsSpace)
+	}
+	msg = s.wrap(msg)
+	plain := renderMarkup(msg, false)
+	out := plain
+	if s.colorEnabled() {
+		out = renderMarkup(msg, true)
+	}
+	s.page(func() {
+		if c, _ := utf8.DecodeLastRuneInString(plain); unicode.IsSpace(c) {
+			n, err = fmt.Fprint(s.writer(), out)
+		} else {
+			n, err = fmt.Fprintln(s.writer(), out)
+		}
+	})
+	return
 }
 
 type ListMode uint
@@ -78,6 +99,10 @@ const (
 	ColumnsDown
 	Inline
 	Rows
+	// Like ColumnsAcross and ColumnsDown, but each column is only as wide
+	// as its widest item (as with ls), so more columns fit on a line.
+	ColumnsAcrossPacked
+	ColumnsDownPacked
 )
 
 //  Print a list of items to os.Stdout. The list can be formatted into rows
@@ -86,12 +111,18 @@ const (
 //      MODE        OPTION  DEFAULT     MEANING
 //      Rows        n/a
 //      Inline      string  " or "      Join terminal element (e.g. "a, b, or c")
-//      Columns*    int     80          Maximum line width
-//  If the default option is desired, it should be passed as nil.
+//      Columns*    int     (width)     Maximum line width
+//  If the default option is desired, it should be passed as nil. Columns are
+//  wrapped at the width of the terminal by default (see Session.Width).
 //      goline.List([]string{"cat", "dog", "go fish"}, goline.ColumnsAcross, nil)
 //      /* Outputs:
 //       *  cat     dog     go fish
 //       */
+//      goline.List([]string{"add", "rm", "mv", "ls", "checkout-branch"}, goline.ColumnsDownPacked, 30)
+//      /* Outputs:
+//       *  add  mv  checkout-branch
+//       *  rm   ls
+//       */
 //      goline.List([]string{"cat", "dog", "go fish"}, goline.ColumnsDown, 15)
 //      /* Outputs:
 //       *  cat     go fish
@@ -109,6 +140,18 @@ const (
 //       */
 //  See subdirectory examples/goline-lists.
 func List(items interface{}, mode ListMode, option interface{}) {
+	DefaultSession.List(items, mode, option)
+}
+
+//  Like List, but the list is written to s.Out. The list is indented and
+//  paged like messages printed by Say.
+func (s *Session) List(items interface{}, mode ListMode, option interface{}) {
+	s.page(func() { fList(s.writer(), items, mode, option, s.textWidth()) })
+}
+
+//  Write a List of items to wr. Columns are wrapped at cols unless option
+//  gives a width.
+func fList(wr io.Writer, items interface{}, mode ListMode, option interface{}, cols int) {
 	ival := reflect.ValueOf(items)
 	itype := ival.Type()
 	if k := itype.Kind(); k != reflect.Slice {
@@ -127,10 +170,14 @@ func List(items interface{}, mode ListMode, option interface{}) {
 		}
 	}
 	switch mode {
+	case ColumnsAcrossPacked:
+		fallthrough
+	case ColumnsDownPacked:
+		fallthrough
 	case ColumnsAcross:
 		fallthrough
 	case ColumnsDown:
-		wrap := 80
+		wrap := cols
 		switch option.(type) {
 		case nil:
 		case int:
@@ -138,10 +185,14 @@ func List(items interface{}, mode ListMode, option interface{}) {
 		default:
 			panic(errors.New("List option of unacceptable type"))
 		}
+		if mode == ColumnsAcrossPacked || mode == ColumnsDownPacked {
+			fListPacked(wr, strs, mode == ColumnsDownPacked, wrap)
+			break
+		}
 
 		var width int
 		for i := range strs {
-			if n := len(strs[i]); n > width {
+			if n := StringWidth(strs[i]); n > width {
 				width = n
 			}
 		}
@@ -152,16 +203,15 @@ func List(items interface{}, mode ListMode, option interface{}) {
 		if ncols <= 1 {
 			// Just print rows if no more than 1 column fits.
 			for i := range strs {
-				SayTrimmed(strs[i])
+				fSay(wr, strs[i], true)
 			}
 			break
 		}
 
 		nrows := (n + ncols - 1) / ncols
 
-		sfmt := fmt.Sprintf("%%-%ds", width)
 		for i := range strs {
-			strs[i] = fmt.Sprintf(sfmt, strs[i])
+			strs[i] = padRight(strs[i], width)
 		}
 
 		switch mode {
@@ -172,7 +222,7 @@ func List(items interface{}, mode ListMode, option interface{}) {
 					end = n
 				}
 				row := strs[i:end]
-				SayTrimmed(strings.Join(row, " "))
+				fSay(wr, strings.Join(row, " "), true)
 			}
 		case ColumnsDown:
 			for i := 0; i < nrows; i++ {
@@ -184,13 +234,13 @@ func List(items interface{}, mode ListMode, option interface{}) {
 					}
 					row = append(row, strs[index])
 				}
-				SayTrimmed(strings.Join(row, " "))
+				fSay(wr, strings.Join(row, " "), true)
 			}
 		}
 	case Inline:
 		n := len(strs)
 		if n == 1 {
-			SayTrimmed(strs[0])
+			fSay(wr, strs[0], true)
 			break
 		}
 		join := "or "
@@ -202,24 +252,93 @@ func List(items interface{}, mode ListMode, option interface{}) {
 			panic(errors.New("List option of unacceptable type"))
 		}
 		if n == 2 {
-			Say(strings.Join([]string{strs[n-2], join, strs[n-2], "\n"}, ""))
+			fSay(wr, strings.Join([]string{strs[n-2], join, strs[n-2], "\n"}, ""), false)
 			break
 		}
 		strs[n-1] = join + strs[n-1]
-		SayTrimmed(strings.Join(strs, ", "))
+		fSay(wr, strings.Join(strs, ", "), true)
 	case Rows:
 		for i := range strs {
-			SayTrimmed(strs[i])
+			fSay(wr, strs[i], true)
 		}
 	default:
 		panic(errors.New("Unknown mode"))
 	}
 }
 
+//  The number of spaces between packed columns.
+const packedColumnSep = 2
+
+//  Write strs in as many columns as fit in wrap columns, each column as wide
+//  as its widest item. Items are ordered down the columns if down is true.
+func fListPacked(wr io.Writer, strs []string, down bool, wrap int) {
+	n := len(strs)
+	widths := make([]int, n)
+	for i := range strs {
+		widths[i] = StringWidth(strs[i])
+	}
+	var colw []int
+	ncols, nrows := 1, n
+	for try := n; try > 1; try-- {
+		rows := (n + try - 1) / try
+		if down {
+			// Fewer columns may be needed to fill the rows.
+			try = (n + rows - 1) / rows
+		}
+		colw = packedWidths(widths, try, rows, down)
+		total := packedColumnSep * (try - 1)
+		for _, w := range colw {
+			total += w
+		}
+		if total <= wrap {
+			ncols, nrows = try, rows
+			break
+		}
+	}
+	if ncols == 1 {
+		for i := range strs {
+			fSay(wr, strs[i], true)
+		}
+		return
+	}
+	sep := strings.Repeat(" ", packedColumnSep)
+	for r := 0; r < nrows; r++ {
+		var row []string
+		for c := 0; c < ncols; c++ {
+			i := r*ncols + c
+			if down {
+				i = c*nrows + r
+			}
+			if i < n {
+				row = append(row, padRight(strs[i], colw[c]))
+			}
+		}
+		fSay(wr, strings.Join(row, sep), true)
+	}
+}
+
+//  The widths of the columns of items with the given widths laid out in ncols
+//  columns and nrows rows.
+func packedWidths(widths []int, ncols, nrows int, down bool) []int {
+	colw := make([]int, ncols)
+	for i, w := range widths {
+		c := i % ncols
+		if down {
+			c = i / nrows
+		}
+		if w > colw[c] {
+			colw[c] = w
+		}
+	}
+	return colw
+}
+
 //  Prompt the user for text input. The result is stored in dest, which must
-//  be a pointer to a native Go type (int, uint16, string, float32, ...).
-//  Slice types are not currently supported. List input must be done with a
-//  *string destination and post-processing.
+//  be a pointer to a native Go type (int, uint16, string, float32, ...), or
+//  to a slice of one ([]int, []string, ...). Slice input is split on the
+//  Question's Sep and each element is checked against its AnswerSet. Other
+//  types can be used if they are registered with RegisterType, or if their
+//  pointers implement encoding.TextUnmarshaler or flag.Value.
 //      package main
 //      import (
 //          "goline"
@@ -233,27 +352,50 @@ func List(items interface{}, mode ListMode, option interface{}) {
 //              q.Panic = func(e os.Error) { panic(e) }
 //          })
 //      }
-func Ask(dest interface{}, msg string, config func(*Question)) (e error) {
+func Ask(dest interface{}, msg string, config func(*Question)) error {
+	return DefaultSession.Ask(dest, msg, config)
+}
+
+//  Like Ask, but the prompt is abandoned when ctx is done. The returned error
+//  is then an ErrorCanceled, unless the Question's DefaultOnTimeout field is
+//  set and ctx's deadline passed, in which case the Default is used.
+//      ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//      defer cancel()
+//      env := "staging"
+//      err := goline.AskContext(ctx, &env, "Environment? ", func(q *goline.Question) {
+//          q.Default = env
+//          q.DefaultOnTimeout = true
+//      })
+func AskContext(ctx context.Context, dest interface{}, msg string, config func(*Question)) error {
+	return DefaultSession.AskContext(ctx, dest, msg, config)
+}
+
+//  Like Ask, but the user is prompted on s.Out and input is read from s.In.
+func (s *Session) Ask(dest interface{}, msg string, config func(*Question)) error {
+	return s.AskContext(context.Background(), dest, msg, config)
+}
+
+//  Like AskContext, but the user is prompted on s.Out and input is read from
+//  s.In.
+func (s *Session) AskContext(ctx context.Context, dest interface{}, msg string, config func(*Question)) (e error) {
 	var q *Question
 	defer func() {
 		if err := recover(); err != nil {
 			switch err.(type) {
 			case error:
 				// Call a panic method...
-				if q.Panic != nil {
-					q.Panic(err.(error))
+				e = err.(error)
+				if q != nil && q.Panic != nil {
+					q.Panic(e)
 				}
 			default:
 				panic(err)
 			}
 		}
 	}()
-	if k := reflect.TypeOf(dest).Kind(); k != reflect.Ptr && k != reflect.Slice {
+	if k := reflect.TypeOf(dest).Kind(); k != reflect.Ptr {
 		panicUnrecoverable(fmt.Errorf("Ask(...) requires a Ptr type, not %s", k.String()))
 		return
-	} else if k == reflect.Slice {
-		panicUnrecoverable(fmt.Errorf("Ask(...) can not currently assign to slices."))
-		return
 	}
 
 	var t Type
@@ -284,43 +426,87 @@ func Ask(dest interface{}, msg string, config func(*Question)) (e error) {
 		t = Float
 	case *string:
 		t = String
+	case *bool:
+		t = Bool
+	case *[]uint, *[]uint8, *[]uint16, *[]uint32, *[]uint64:
+		t = UintSlice
+	case *[]int, *[]int8, *[]int16, *[]int32, *[]int64:
+		t = IntSlice
+	case *[]float32, *[]float64:
+		t = FloatSlice
+	case *[]string:
+		t = StringSlice
 	default:
-		fmt.Errorf("Unusable destination")
+		conv := converterFor(reflect.TypeOf(dest).Elem())
+		if conv == nil {
+			panicUnrecoverable(fmt.Errorf("Unusable destination type %T", dest))
+			return
+		}
+		q = newQuestion(Custom)
+		q.conv = conv
+	}
+	if q == nil {
+		q = newQuestion(t)
 	}
-	q = newQuestion(t)
 	q.Question = msg
+	q.ID = msg
+	q.Edit = s.Edit
 	if config != nil {
 		config(q)
 	}
 
 	if err := q.tryFirstAnswer(); err == nil && q.val != nil {
-		if err := q.setDest(dest); err != nil {
-			panicUnrecoverable(err)
-			q.val = nil
+		err = q.assign(dest)
+		if err == nil {
+			return
 		}
-		return
+		// Prompt the user if the FirstAnswer was not acceptable.
+		panicUnrecoverable(err)
+		q.val = nil
 	}
 
 	prompt := msg
+	timedOut := false
 	contFunc := func(err error) {
-		Say(fmt.Sprintf("Error: %s\n", err.Error()))
+		s.Say(Style("Error: "+EscapeMarkup(err.Error()), "error") + "\n")
 		prompt = q.Responses[AskOnError]
 	}
-	r := bufio.NewReader(os.Stdin)
 	for {
 		tail := stringSuffixFunc(prompt, unicode.IsSpace)
-		Say(prompt + q.defaultString(tail))
-		var resp []byte
-		for cont := true; cont; {
-			s, isPrefix, err := r.ReadLine()
-			if err != nil {
-				panicUnrecoverable(err)
-				return
+		display := prompt + EscapeMarkup(q.defaultString(tail))
+		s.Say(display)
+		resp, err := s.readAnswer(ctx, q, display)
+		if err != nil && ctx.Err() != nil {
+			// End the abandoned prompt's line. The Default is tried only once.
+			s.Say("")
+			useDefault := q.DefaultOnTimeout && q.Default != nil && !timedOut
+			if useDefault && ctx.Err() == context.DeadlineExceeded {
+				resp, err, timedOut = nil, nil, true
+			} else {
+				err = ErrorCanceled{ctx.Err()}
 			}
-			resp = append(resp, s...)
-			cont = isPrefix
 		}
-		if err := q.parse(string(resp)); err != nil {
+		if err != nil {
+			panicUnrecoverable(err)
+			contFunc(err)
+			continue
+		}
+		err = q.parse(string(resp))
+		if q.Hidden {
+			s.wipeInput(resp)
+		}
+		if err != nil {
+			panicUnrecoverable(err)
+			// Offer the closest member of the AnswerSet instead.
+			switch sugg, yes, cerr := s.confirmSuggestion(ctx, q, err); {
+			case cerr != nil:
+				err = cerr
+			case yes:
+				resp = []byte(sugg)
+				err = q.parse(sugg)
+			}
+		}
+		if err != nil {
 			panicUnrecoverable(err)
 			contFunc(err)
 			continue
@@ -329,11 +515,16 @@ func Ask(dest interface{}, msg string, config func(*Question)) (e error) {
 		// Cast the result from a wide (e.g. 64bit) type to the desired type.
 		// This should not fail under any normal circumstances, so failure
 		// should break the loop.
-		if err := q.setDest(dest); err != nil {
+		if err := q.assign(dest); err != nil {
 			panicUnrecoverable(err)
 			contFunc(err)
 			continue
 		}
+		if q.Hidden {
+			// Don't keep a reference to the secret.
+			q.val = nil
+		}
+		s.remember(q, resp)
 		break
 	}
 	return
@@ -342,41 +533,79 @@ func Ask(dest interface{}, msg string, config func(*Question)) (e error) {
 //  Corresponds to HighLine's `agree` method. A simple wrapper around Ask for
 //  yes/no questions. Confirm is given a string to prompt the user with, and a
 //  default (or expected) value (yes=true, no=false). Returns the value of the
-//  input.
+//  input, or false if an error was encountered. Answers are read with a Bool
+//  Question, so any of the Question's BoolWords are accepted ("y", "YES",
+//  "true", "on", ...). A StringSet given to Question.In is used as the
+//  vocabulary instead, its words beginning with "y" meaning yes.
 //      if Confirm("Fetch data from the server? ", true, nil) {
 //          var server string
 //          Ask(&server, "Server (host:port)? ", nil)
 //          // Fetch some data...
 //      }
 func Confirm(question string, yes bool, config func(*Question)) bool {
-	def := "no"
-	if yes {
-		def = "yes"
-	}
+	return DefaultSession.Confirm(question, yes, config)
+}
+
+//  Like Confirm, but any error encountered (such as io.EOF) is returned along
+//  with a false value. This distinguishes a "no" answer from a failure to get
+//  an answer.
+func ConfirmErr(question string, yes bool, config func(*Question)) (bool, error) {
+	return DefaultSession.ConfirmErr(question, yes, config)
+}
+
+//  Like Confirm, but the prompt is abandoned when ctx is done. Any error
+//  encountered is returned along with a false value. See AskContext.
+func ConfirmContext(ctx context.Context, question string, yes bool, config func(*Question)) (bool, error) {
+	return DefaultSession.ConfirmContext(ctx, question, yes, config)
+}
+
+//  Like Confirm, but the user is prompted on s.Out and input is read from s.In.
+func (s *Session) Confirm(question string, yes bool, config func(*Question)) bool {
+	ok, _ := s.ConfirmContext(context.Background(), question, yes, config)
+	return ok
+}
+
+//  Like ConfirmErr, but the user is prompted on s.Out and input is read from
+//  s.In.
+func (s *Session) ConfirmErr(question string, yes bool, config func(*Question)) (bool, error) {
+	return s.ConfirmContext(context.Background(), question, yes, config)
+}
 
-	var okstr string
-	var err error
-	Ask(&okstr, question, func(q *Question) {
-		q.Default = def
-		q.In(StringSet{"yes", "y", "no", "n"})
+//  Like ConfirmContext, but the user is prompted on s.Out and input is read
+//  from s.In.
+func (s *Session) ConfirmContext(ctx context.Context, question string, yes bool, config func(*Question)) (bool, error) {
+	var ok bool
+	err := s.AskContext(ctx, &ok, question, func(q *Question) {
+		q.Default = yes
 		if config != nil {
 			config(q)
 		}
-		if q.Panic != nil {
-			f := q.Panic
-			q.Panic = func(e error) {
-				err = e
-				f(e)
-			}
+		// A StringSet of answers (and a string Default) from configurations
+		// written before Confirm read Bool answers.
+		if set, isSet := q.set.(StringSet); isSet {
+			q.BoolWords, q.set = confirmWords(set), nil
+		}
+		if def, isStr := q.Default.(string); isStr {
+			q.Default = strings.HasPrefix(strings.ToLower(def), "y")
 		}
 	})
 	if err != nil {
-		return false
+		return false, err
 	}
-	if okstr[0] == 'y' {
-		return true
+	return ok, nil
+}
+
+//  The vocabulary of a StringSet of answers to Confirm. As before Confirm
+//  read Bool answers, words beginning with "y" mean yes.
+func confirmWords(set StringSet) (words BoolWords) {
+	for _, w := range set {
+		if strings.HasPrefix(strings.ToLower(w), "y") {
+			words.True = append(words.True, w)
+		} else {
+			words.False = append(words.False, w)
+		}
 	}
-	return false
+	return words
 }
 
 func splitShellCmd(cmd string) (name, args string) {
@@ -396,52 +625,106 @@ func splitShellCmd(cmd string) (name, args string) {
 //  of the chosen item, and the item itself in an empty interface. See
 //  Menu for more information about configuring the prompt.
 func Choose(config func(*Menu)) (i int, v interface{}) {
+	return DefaultSession.Choose(config)
+}
+
+//  Like Choose, but the prompt is abandoned when ctx is done. Errors are
+//  returned instead of causing a run-time panic (though Menu.Panic is still
+//  called). See AskContext.
+func ChooseContext(ctx context.Context, config func(*Menu)) (i int, v interface{}, err error) {
+	return DefaultSession.ChooseContext(ctx, config)
+}
+
+//  Like Choose, but the menu is written to s.Out and input is read from s.In.
+func (s *Session) Choose(config func(*Menu)) (i int, v interface{}) {
+	m, i, v, err := s.choose(context.Background(), config)
+	if err != nil && m.Panic == nil {
+		panic(err)
+	}
+	return
+}
+
+//  Like ChooseContext, but the menu is written to s.Out and input is read from
+//  s.In.
+func (s *Session) ChooseContext(ctx context.Context, config func(*Menu)) (i int, v interface{}, err error) {
+	_, i, v, err = s.choose(ctx, config)
+	return
+}
+
+func (s *Session) choose(ctx context.Context, config func(*Menu)) (m *Menu, i int, v interface{}, err error) {
 	i = -1
-	m := newMenu()
+	m = newMenu()
 	config(m)
 	if m.Len() == 0 {
+		err = ErrorNoChoices
 		if m.Panic != nil {
-			m.Panic(ErrorNoChoices)
-			return
+			m.Panic(err)
 		}
-		panic(ErrorNoChoices)
-	}
-
-	if len(m.Header) > 0 {
-		Say(m.Header)
+		return
 	}
 
 	raw, selections, tr := m.Selections()
-	List(raw, m.ListMode, nil)
-	ok := true
-	var resp, args string
-	Ask(&resp, m.Question, func(q *Question) {
-		var set AnswerSet = StringSet(selections)
-		if m.Shell {
-			set = shellCommandSet(StringCompletionSet(set.(StringSet)))
+	if fd, ok := s.selectTerminal(m); ok {
+		answer := s.selectChoice
+		if m.Filter {
+			answer = s.filterChoice
 		}
-		q.In(set)
-		q.Panic = func(err error) {
-			ok = false
-			if m.Panic != nil {
-				m.Panic(err)
-			} else {
-				panic(err)
+		for {
+			if i, err = answer(ctx, fd, m, raw); err != nil {
+				break
+			}
+			if v, err = m.act(i, m.Choices[i].String(), ""); !CanRecover(err) {
+				break
 			}
+			s.commandError(err)
+		}
+		if err != nil && m.Panic != nil {
+			m.Panic(err)
 		}
-	})
-	if !ok {
 		return
 	}
-	if m.Shell {
-		resp, args = splitShellCmd(resp)
-	}
 
-	i = tr[resp]
-	v = m.Choices[i]
-	if m.Actions[i] != nil {
-		m.Actions[i](resp, args)
+	// The menu is paged before the user is prompted.
+	if !m.unlisted {
+		s.page(func() {
+			if len(m.Header) > 0 {
+				s.Say(m.Header)
+			}
+			s.List(raw, m.ListMode, nil)
+		})
 	}
+	var resp, args string
+	for {
+		err = s.AskContext(ctx, &resp, m.Question, func(q *Question) {
+			var set AnswerSet = StringSet(selections)
+			switch {
+			case m.Shell:
+				set = shellCommandSet(StringCompletionSet(selections))
+			case m.Filter:
+				set = FuzzySet(selections)
+			}
+			q.In(set)
+			q.ConfirmSuggestion = m.ConfirmSuggestion
+			q.Edit = q.Edit || m.edit
+			q.Panic = m.Panic
+		})
+		if err != nil {
+			return
+		}
+		if m.Shell {
+			resp, args = splitShellCmd(resp)
+		}
 
+		i = tr[resp]
+		// Commands failing recoverably (e.g. given bad arguments) are
+		// reported and the user is prompted again.
+		if v, err = m.act(i, resp, args); !CanRecover(err) {
+			break
+		}
+		s.commandError(err)
+	}
+	if err != nil && m.Panic != nil {
+		m.Panic(err)
+	}
 	return
 }
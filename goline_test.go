@@ -13,8 +13,8 @@ package goline
 import (
     "testing"
     "bytes"
+    "errors"
     "io"
-    "os"
 )
 
 //  Returns true if f raises a runtime panic, false otherwise.
@@ -34,9 +34,9 @@ func FuncOutput(fn func(wr io.Writer)) string {
     return string(pout.Bytes())
 }
 
-func StringsShouldBeEqual(a, b string) os.Error {
+func StringsShouldBeEqual(a, b string) error {
     if a != b {
-        return os.NewError("Not Equal")
+        return errors.New("Not Equal")
     }
     return nil
 }
@@ -81,7 +81,7 @@ func TestList(T *testing.T) {
     // */
     OutputEqualityTest("It prints columns alphabetically left to right",
         func(wr io.Writer) {
-            fList(wr, []string{"cat", "dog", "go fish"}, ColumnsAcross, 15)
+            fList(wr, []string{"cat", "dog", "go fish"}, ColumnsAcross, 15, 80)
         }, "cat     dog\ngo fish\n", T)
 
     //      goline.List([]string{"cat", "dog", "go fish"}, goline.ColumnsDown, 15)
@@ -91,16 +91,34 @@ func TestList(T *testing.T) {
     //       */
     OutputEqualityTest("It prints columns alphabetically up to down",
         func(wr io.Writer) {
-            fList(wr, []string{"cat", "dog", "go fish"}, ColumnsDown, 15)
+            fList(wr, []string{"cat", "dog", "go fish"}, ColumnsDown, 15, 80)
         }, "cat     go fish\ndog\n", T)
 
+    //      goline.List([]string{"add", "rm", "mv", "ls", "checkout-branch"}, goline.ColumnsDownPacked, 30)
+    //      /* Outputs:
+    //       *  add  mv  checkout-branch
+    //       *  rm   ls
+    //       */
+    OutputEqualityTest("It packs columns of varying width",
+        func(wr io.Writer) {
+            fList(wr, []string{"add", "rm", "mv", "ls", "checkout-branch"}, ColumnsDownPacked, 30, 80)
+        }, "add  mv  checkout-branch\nrm   ls\n", T)
+    OutputEqualityTest("It packs columns across",
+        func(wr io.Writer) {
+            fList(wr, []string{"add", "rm", "mv", "ls", "checkout-branch"}, ColumnsAcrossPacked, 30, 80)
+        }, "add              rm  mv  ls\ncheckout-branch\n", T)
+    OutputEqualityTest("It packs into a single column when nothing fits",
+        func(wr io.Writer) {
+            fList(wr, []string{"checkout-branch", "ls"}, ColumnsAcrossPacked, 10, 80)
+        }, "checkout-branch\nls\n", T)
+
     //      goline.List([]string{"cat", "dog", "go fish"}, goline.Inline, "and ")
     //      /* Outputs:
     //       *  cat, dog, and go fish
     //       */
     OutputEqualityTest("It prints inline lists with custom separators",
         func(wr io.Writer) {
-            fList(wr, []string{"cat", "dog", "go fish"}, Inline, "and ")
+            fList(wr, []string{"cat", "dog", "go fish"}, Inline, "and ", 80)
         }, "cat, dog, and go fish\n", T)
 
     //      goline.List([]string{"cat", "dog", "go fish"}, goline.Rows, nil)
@@ -111,6 +129,6 @@ func TestList(T *testing.T) {
     //       */
     OutputEqualityTest("It prints everything on separate rows",
         func(wr io.Writer) {
-            fList(wr, []string{"cat", "dog", "go fish"}, Rows, nil)
+            fList(wr, []string{"cat", "dog", "go fish"}, Rows, nil, 80)
         }, "cat\ndog\ngo fish\n", T)
 }
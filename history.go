@@ -0,0 +1,210 @@
+package goline
+
+/*
+ *  Filename:    history.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:53:50 UTC 2026
+ *  Description: Input history recalled by the line editor.
+ */
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//  The number of entries kept for each question when a History's Max is not
+//  positive.
+const DefaultHistorySize = 500
+
+//  A History records the answers typed at each question, keyed by the
+//  Question's ID. The line editor recalls them with the Up and Down keys.
+//  Entries are de-duplicated; entering an answer already in the history
+//  moves it to the end. If File is set, the history is loaded from the file
+//  when first used and the file is rewritten when entries are added.
+//  Sessions only record answers once given a History.
+//      s := goline.NewSession(os.Stdin, os.Stdout)
+//      s.Edit = true
+//      s.History = goline.NewHistory(filepath.Join(home, ".admin_history"), 1000)
+//
+//  The file holds one entry per line, as a quoted question ID and a quoted
+//  entry separated by a space.
+type History struct {
+	// The file the history is persisted in. If empty, the history is only
+	// kept in memory.
+	File string
+	// The maximum number of entries kept for each question.
+	Max     int
+	mu      sync.Mutex
+	entries map[string][]string
+	loaded  bool
+}
+
+//  Create a new History persisted in file (which may be empty), keeping at
+//  most max entries for each question.
+func NewHistory(file string, max int) *History {
+	return &History{File: file, Max: max}
+}
+
+func (h *History) max() int {
+	if h.Max > 0 {
+		return h.Max
+	}
+	return DefaultHistorySize
+}
+
+//  Returns the entries recorded for the question id, oldest first.
+func (h *History) Entries(id string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.load()
+	return append([]string(nil), h.entries[id]...)
+}
+
+//  Record an entry for the question id and save the history if it has a
+//  File. Empty entries are ignored.
+func (h *History) Add(id, entry string) error {
+	if entry == "" {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.load()
+	h.add(id, entry)
+	if h.File == "" {
+		return nil
+	}
+	return h.save()
+}
+
+func (h *History) add(id, entry string) {
+	if h.entries == nil {
+		h.entries = make(map[string][]string)
+	}
+	old := h.entries[id]
+	entries := make([]string, 0, len(old)+1)
+	for _, e := range old {
+		if e != entry {
+			entries = append(entries, e)
+		}
+	}
+	entries = append(entries, entry)
+	if n := len(entries) - h.max(); n > 0 {
+		entries = entries[n:]
+	}
+	h.entries[id] = entries
+}
+
+//  Load the history from h.File once. A missing or malformed file is
+//  treated as an empty history.
+func (h *History) load() {
+	if h.loaded || h.File == "" {
+		return
+	}
+	h.loaded = true
+	h.read()
+}
+
+//  Read the entries in h.File, adding them to the history.
+func (h *History) Load() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.read()
+}
+
+//  Like Load, but h.mu must be held.
+func (h *History) read() error {
+	f, err := os.Open(h.File)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		id, entry, ok := parseHistoryLine(scanner.Text())
+		if ok {
+			h.add(id, entry)
+		}
+	}
+	return scanner.Err()
+}
+
+func parseHistoryLine(line string) (id, entry string, ok bool) {
+	qid, err := strconv.QuotedPrefix(line)
+	if err != nil {
+		return "", "", false
+	}
+	rest := strings.TrimPrefix(line[len(qid):], " ")
+	if id, err = strconv.Unquote(qid); err != nil {
+		return "", "", false
+	}
+	if entry, err = strconv.Unquote(rest); err != nil {
+		return "", "", false
+	}
+	return id, entry, true
+}
+
+//  Returned when saving a History that has no File.
+var ErrorNoHistoryFile = NewError("History has no File")
+
+//  Write the history to h.File, replacing its contents.
+func (h *History) Save() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.save()
+}
+
+func (h *History) save() error {
+	if h.File == "" {
+		return ErrorNoHistoryFile
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(h.File), filepath.Base(h.File)+".*")
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	for id, entries := range h.entries {
+		for _, entry := range entries {
+			w.WriteString(strconv.Quote(id) + " " + strconv.Quote(entry) + "\n")
+		}
+	}
+	if err = w.Flush(); err == nil {
+		err = tmp.Chmod(0600)
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), h.File)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+//  Record the answer resp to q in the Session's History. Hidden questions
+//  and questions with NoHistory set are never recorded.
+func (s *Session) remember(q *Question, resp []byte) {
+	if s.History == nil || q.Hidden || q.NoHistory {
+		return
+	}
+	// The history is a convenience, failing to save it does not fail Ask.
+	s.History.Add(q.ID, string(resp))
+}
+
+//  Returns the History entries that can be recalled while answering q.
+func (s *Session) recall(q *Question) []string {
+	if s.History == nil || q == nil || q.Hidden || q.NoHistory {
+		return nil
+	}
+	return s.History.Entries(q.ID)
+}
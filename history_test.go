@@ -0,0 +1,107 @@
+package goline
+/*
+ *  Filename:    history_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:53:50 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+func TestHistoryAdd(T *testing.T) {
+    h := NewHistory("", 3)
+    for _, entry := range []string{"a", "b", "", "a", "c", "d"} {
+        h.Add("q", entry)
+    }
+    if e := strings.Join(h.Entries("q"), ","); e != "a,c,d" {
+        T.Errorf("Unexpected entries %#v", e)
+    }
+    if e := h.Entries("other"); len(e) != 0 {
+        T.Errorf("Unexpected entries %#v", e)
+    }
+}
+
+func TestHistorySaveNoFile(T *testing.T) {
+    h := NewHistory("", 0)
+    if err := h.Add("?", "x"); err != nil {
+        T.Errorf("Unexpected error %v", err)
+    }
+    if err := h.Save(); err != ErrorNoHistoryFile {
+        T.Errorf("Unexpected error %v", err)
+    }
+}
+
+func TestHistoryFile(T *testing.T) {
+    file := filepath.Join(T.TempDir(), "history")
+    h := NewHistory(file, 0)
+    h.Add("Command? ", "echo \"hi\"\tthere")
+    h.Add("Name? ", "gopher")
+    h = NewHistory(file, 0)
+    if e := h.Entries("Command? "); len(e) != 1 || e[0] != "echo \"hi\"\tthere" {
+        T.Errorf("Unexpected entries %#v", e)
+    }
+    if e := h.Entries("Name? "); len(e) != 1 || e[0] != "gopher" {
+        T.Errorf("Unexpected entries %#v", e)
+    }
+}
+
+func TestHistoryLoadConcurrent(T *testing.T) {
+    file := filepath.Join(T.TempDir(), "history")
+    NewHistory(file, 0).Add("Name? ", "gopher")
+    h := NewHistory(file, 0)
+    done := make(chan bool)
+    go func() {
+        for i := 0; i < 10; i++ {
+            h.Add("Name? ", "gordon")
+        }
+        done <- true
+    }()
+    for i := 0; i < 10; i++ {
+        if err := h.Load(); err != nil {
+            T.Errorf("Unexpected error %v", err)
+        }
+    }
+    <-done
+    if e := strings.Join(h.Entries("Name? "), ","); e != "gopher,gordon" && e != "gordon,gopher" {
+        T.Errorf("Unexpected entries %#v", e)
+    }
+}
+
+func TestHistoryRecall(T *testing.T) {
+    s := NewSession(strings.NewReader("ls\n"), new(bytes.Buffer))
+    var cmd string
+    if s.Ask(&cmd, "$ ", nil); s.History != nil {
+        T.Errorf("History recorded by default")
+    }
+
+    s = NewSession(strings.NewReader("ls\n\npwd\nsecret\n"), new(bytes.Buffer))
+    s.History = NewHistory("", 0)
+    for i := 0; i < 3; i++ {
+        var cmd string
+        s.Ask(&cmd, "$ ", func(q *Question) { q.Default = "" })
+    }
+    var secret string
+    s.Ask(&secret, "$ ", func(q *Question) { q.Hidden = true })
+    if e := strings.Join(s.History.Entries("$ "), ","); e != "ls,pwd" {
+        T.Errorf("Unexpected entries %#v", e)
+    }
+
+    q := newQuestion(String)
+    q.ID = "$ "
+    s.In = strings.NewReader("\x1b[A\x1b[A\x1b[A -l\x1b[B\x1b[A\r")
+    line, err := s.editLine(context.Background(), q, "$ ")
+    if err != nil || string(line) != "ls -l" {
+        T.Errorf("Unexpected line %#v %v", string(line), err)
+    }
+    q.NoHistory = true
+    s.In = strings.NewReader("x\x1b[A\r")
+    if line, _ := s.editLine(context.Background(), q, "$ "); string(line) != "x" {
+        T.Errorf("Unexpected line %#v", string(line))
+    }
+}
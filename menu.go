@@ -121,13 +121,37 @@ type Menu struct {
 	IndexMode
 	// The selection mode for the choice prompt.
 	SelectMode
-	// Use shell type matching.
+	// Use shell type matching. The first word of an answer selects a choice
+	// and the rest of the answer is passed to its action as arguments (see
+	// Menu.Command).
 	Shell bool
 	// The index and suffix used for all choices if IndexMode is Literal.
 	Index       string
 	IndexSuffix string
+	// Select the choice by moving a highlight with the arrow keys (or j and
+	// k) and pressing Enter, when input and output are terminals. Menus are
+	// otherwise answered by typing a selection.
+	Interactive bool
+	// The number of choices displayed at once by an Interactive menu. The
+	// default fills the terminal.
+	Viewport int
+	// Narrow the displayed choices to those matching a fuzzy query typed
+	// by the user, best match first (see FuzzyMatch), when input and output
+	// are terminals. Typed selections are otherwise matched fuzzily.
+	Filter bool
+	// Ask the user to confirm the closest choice when a typed selection is
+	// not recognized. See Question.ConfirmSuggestion.
+	ConfirmSuggestion bool
+	// The minimum and maximum number of choices selected with ChooseMany.
+	// If Max is not positive there is no maximum.
+	Min, Max int
+	// Descriptions of choices by name, listed by the help command of a REPL.
+	// See Menu.Describe.
+	Descriptions map[string]string
 	// A handler function for any errors encountered.
-	Panic func(error)
+	Panic    func(error)
+	edit     bool
+	unlisted bool
 }
 
 func newMenu() *Menu {
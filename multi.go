@@ -0,0 +1,214 @@
+package goline
+
+/*
+ *  Filename:    multi.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:01:40 UTC 2026
+ *  Description: Menus allowing several choices to be selected.
+ */
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//  Prompt the user to choose any number of items from a list of choices.
+//  The answer is a list of selections separated by commas or spaces. Each
+//  is a choice's index or name (see Menu.SelectMode), a range of indices
+//  ("3-5"), "all" or "none". A name containing spaces must be separated
+//  from other selections by commas.
+//      Which branches should be deleted? 0,2,5-7
+//  The number of choices can be limited with Menu.Min and Menu.Max. The
+//  indices of the chosen items are returned in increasing order, with the
+//  items themselves. The Action of each chosen item is called with the
+//  item's name. Menu.Shell is ignored. If the command of a chosen item (see
+//  Menu.Command) returns a RecoverableError it is printed and the user is
+//  prompted again; the commands of the items before it have already run.
+func ChooseMany(config func(*Menu)) (is []int, vs []interface{}) {
+	return DefaultSession.ChooseMany(config)
+}
+
+//  Like ChooseMany, but the prompt is abandoned when ctx is done. See
+//  ChooseContext.
+func ChooseManyContext(ctx context.Context, config func(*Menu)) (is []int, vs []interface{}, err error) {
+	return DefaultSession.ChooseManyContext(ctx, config)
+}
+
+//  Like ChooseMany, but the menu is written to s.Out and input is read from
+//  s.In.
+func (s *Session) ChooseMany(config func(*Menu)) (is []int, vs []interface{}) {
+	m, is, vs, err := s.chooseMany(context.Background(), config)
+	if err != nil && m.Panic == nil {
+		panic(err)
+	}
+	return
+}
+
+//  Like ChooseManyContext, but the menu is written to s.Out and input is
+//  read from s.In.
+func (s *Session) ChooseManyContext(ctx context.Context, config func(*Menu)) (is []int, vs []interface{}, err error) {
+	_, is, vs, err = s.chooseMany(ctx, config)
+	return
+}
+
+func (s *Session) chooseMany(ctx context.Context, config func(*Menu)) (m *Menu, is []int, vs []interface{}, err error) {
+	m = newMenu()
+	config(m)
+	if m.Len() == 0 {
+		err = ErrorNoChoices
+		if m.Panic != nil {
+			m.Panic(err)
+		}
+		return
+	}
+
+	raw, selections, tr := m.Selections()
+	s.page(func() {
+		if len(m.Header) > 0 {
+			s.Say(m.Header)
+		}
+		s.List(raw, m.ListMode, nil)
+	})
+	set := &multiSelectSet{m, selections, tr}
+	var resp string
+	for {
+		err = s.AskContext(ctx, &resp, m.Question, func(q *Question) {
+			q.In(set)
+			q.Panic = m.Panic
+		})
+		if err != nil {
+			return
+		}
+		if is, vs, err = set.act(resp); !CanRecover(err) {
+			break
+		}
+		s.commandError(err)
+	}
+	if err != nil && m.Panic != nil {
+		m.Panic(err)
+	}
+	return
+}
+
+//  Run the actions of the choices selected by resp, in order, stopping at
+//  the first error.
+func (set *multiSelectSet) act(resp string) (is []int, vs []interface{}, err error) {
+	is, _ = set.parse(resp)
+	for _, i := range is {
+		var v interface{}
+		if v, err = set.m.act(i, set.m.Choices[i].String(), ""); err != nil {
+			return nil, nil, err
+		}
+		vs = append(vs, v)
+	}
+	return is, vs, nil
+}
+
+//  The answers to a ChooseMany prompt. Complete checks that an answer can
+//  be parsed and that the number of choices it selects is allowed.
+type multiSelectSet struct {
+	m          *Menu
+	selections []string
+	tr         map[string]int
+}
+
+func (set *multiSelectSet) String() string {
+	return fmt.Sprintf("selections of %v", StringSet(set.selections))
+}
+
+func (set *multiSelectSet) Has(x interface{}) bool {
+	str, ok := x.(string)
+	if !ok {
+		panic(makeErrorMemberType(set, x))
+	}
+	_, err := set.parse(str)
+	return err == nil
+}
+
+func (set *multiSelectSet) Complete(x interface{}) (interface{}, error) {
+	str, ok := x.(string)
+	if !ok {
+		panic(makeErrorMemberType(set, x))
+	}
+	if _, err := set.parse(str); err != nil {
+		return nil, err
+	}
+	return str, nil
+}
+
+//  Parse a list of selections, returning the indices of the chosen items in
+//  increasing order.
+func (set *multiSelectSet) parse(str string) ([]int, error) {
+	chosen := make(map[int]bool)
+	choose := func(tok string) error {
+		if i, ok := set.tr[tok]; ok {
+			chosen[i] = true
+			return nil
+		}
+		switch strings.ToLower(tok) {
+		case "all":
+			for i := range set.m.Choices {
+				chosen[i] = true
+			}
+			return nil
+		case "none":
+			return nil
+		}
+		first, last, err := set.parseRange(tok)
+		if err != nil {
+			return err
+		}
+		for i := first; i <= last; i++ {
+			chosen[i] = true
+		}
+		return nil
+	}
+	for _, list := range strings.Split(str, ",") {
+		// Names containing spaces are selected when separated by commas.
+		if i, ok := set.tr[strings.TrimSpace(list)]; ok {
+			chosen[i] = true
+			continue
+		}
+		for _, tok := range strings.Fields(list) {
+			if err := choose(tok); err != nil {
+				return nil, err
+			}
+		}
+	}
+	is := make([]int, 0, len(chosen))
+	for i := range chosen {
+		is = append(is, i)
+	}
+	sort.Ints(is)
+	switch n := len(is); {
+	case n < set.m.Min:
+		return nil, NewErrorRecoverable(fmt.Sprintf("Choose at least %d (%d chosen)", set.m.Min, n))
+	case set.m.Max > 0 && n > set.m.Max:
+		return nil, NewErrorRecoverable(fmt.Sprintf("Choose at most %d (%d chosen)", set.m.Max, n))
+	case n == 0 && strings.TrimSpace(str) == "":
+		return nil, NewErrorRecoverable("Nothing chosen (answer \"none\" to choose nothing)")
+	}
+	return is, nil
+}
+
+//  Parse a range of indices "first-last".
+func (set *multiSelectSet) parseRange(tok string) (first, last int, err error) {
+	a, b, ok := strings.Cut(tok, "-")
+	if !ok || !set.isIndex(a) || !set.isIndex(b) {
+		return 0, 0, NewErrorRecoverable(fmt.Sprintf("Unrecognized selection %q", tok))
+	}
+	first, last = set.tr[a], set.tr[b]
+	if first > last {
+		return 0, 0, NewErrorRecoverable(fmt.Sprintf("Empty range %q", tok))
+	}
+	return first, last, nil
+}
+
+//  Returns true if sel selects a choice by its index.
+func (set *multiSelectSet) isIndex(sel string) bool {
+	i, ok := set.tr[sel]
+	m := set.m
+	return ok && m.UseIndex() && m.SelectIndices() && !m.UseLiteral() && m.getIndexNoSuffix(i) == sel
+}
@@ -0,0 +1,96 @@
+package goline
+/*
+ *  Filename:    multi_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:01:40 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "fmt"
+    "strings"
+    "testing"
+)
+
+var branches = []string{"main", "fix-1", "feature-x", "old", "tmp"}
+
+func testChooseMany(T *testing.T, input string, config func(*Menu), expect string) string {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(input), out)
+    is, vs, err := s.ChooseManyContext(context.Background(), func(m *Menu) {
+        m.Question = "Delete? "
+        for _, b := range branches {
+            m.Choice(b, nil)
+        }
+        if config != nil {
+            config(m)
+        }
+    })
+    if err != nil {
+        T.Errorf("%#v: Unexpected error %v", input, err)
+    } else if got := fmt.Sprint(is, vs); got != expect {
+        T.Errorf("%#v: Unexpected choices %s != %s", input, got, expect)
+    }
+    return out.String()
+}
+
+func TestChooseMany(T *testing.T) {
+    testChooseMany(T, "1,3\n", nil, "[1 3] [fix-1 old]")
+    testChooseMany(T, "4 0-2, 1\n", nil, "[0 1 2 4] [main fix-1 feature-x tmp]")
+    testChooseMany(T, "feature-x tmp\n", nil, "[2 4] [feature-x tmp]")
+    testChooseMany(T, "all\n", nil, "[0 1 2 3 4] [main fix-1 feature-x old tmp]")
+    out := testChooseMany(T, "\nnone\n", nil, "[] []")
+    if !strings.Contains(out, "Nothing chosen") {
+        T.Errorf("Empty answer accepted %#v", out)
+    }
+    testChooseMany(T, "b-d\n", func(m *Menu) { m.IndexMode = Letter }, "[1 2 3] [fix-1 feature-x old]")
+    testChooseMany(T, "go fish, 0 tmp\n", func(m *Menu) { m.Choice("go fish", nil) }, "[0 4 5] [main tmp go fish]")
+
+    out = testChooseMany(T, "3-1\nfoo\n\nall\n1,2\n", func(m *Menu) {
+        m.Min, m.Max = 1, 2
+    }, "[1 2] [fix-1 feature-x]")
+    for _, msg := range []string{"Empty range", "Unrecognized selection", "at least 1", "at most 2"} {
+        if !strings.Contains(out, msg) {
+            T.Errorf("Error %#v not printed %#v", msg, out)
+        }
+    }
+
+    var actions []string
+    s := NewSession(strings.NewReader("0-1\n"), new(bytes.Buffer))
+    s.ChooseMany(func(m *Menu) {
+        for _, b := range branches {
+            m.Choice(b, func(name, arg string) { actions = append(actions, name) })
+        }
+    })
+    if fmt.Sprint(actions) != "[main fix-1]" {
+        T.Errorf("Unexpected actions %v", actions)
+    }
+}
+
+func TestChooseManyCommandError(T *testing.T) {
+    var deleted []string
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("0-2\n0,1\n"), out)
+    is, _ := s.ChooseMany(func(m *Menu) {
+        for _, b := range branches[1:4] {
+            m.Command(b, func(argv []string) error {
+                if argv[0] == "old" {
+                    return NewErrorRecoverable("old is protected")
+                }
+                deleted = append(deleted, argv[0])
+                return nil
+            })
+        }
+    })
+    if fmt.Sprint(is) != "[0 1]" {
+        T.Errorf("Unexpected choices %v", is)
+    }
+    if !strings.Contains(out.String(), "Error: old is protected\n") {
+        T.Errorf("Error not printed %#v", out.String())
+    }
+    if fmt.Sprint(deleted) != "[fix-1 feature-x fix-1 feature-x]" {
+        T.Errorf("Unexpected commands run %v", deleted)
+    }
+}
@@ -0,0 +1,137 @@
+package goline
+
+/*
+ *  Filename:    page.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:00:38 UTC 2026
+ *  Description: Paging of long output.
+ */
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+)
+
+//  A Session's PageAt value that pages output at the terminal height (see
+//  Session.Height).
+const PageAuto = -1
+
+//  The prompt displayed between pages of output.
+var MorePrompt = "{{reverse}}-- more --{{/}}"
+
+//  Run write, which writes to s.writer(), paging its output if it is longer
+//  than s.PageAt lines. Calls to page made by write are not paged separately.
+func (s *Session) page(write func()) {
+	if s.paging != nil || s.PageAt == 0 {
+		write()
+		return
+	}
+	buf := new(bytes.Buffer)
+	s.paging = buf
+	func() {
+		defer func() { s.paging = nil }()
+		write()
+	}()
+	s.showPaged(buf.Bytes())
+}
+
+//  The number of lines per page.
+func (s *Session) pageLines() int {
+	if s.PageAt == PageAuto {
+		// Leave a line for the more prompt.
+		if h := s.Height() - 1; h > 1 {
+			return h
+		}
+		return 1
+	}
+	return s.PageAt
+}
+
+//  Write p to s.Out, a page at a time. If s.Pager is set, long output is
+//  piped to the pager command instead. If the pager cannot be run, output is
+//  paged as if s.Pager were not set. Output is only paused at the more
+//  prompt when s.In is a terminal.
+func (s *Session) showPaged(p []byte) {
+	n := s.pageLines()
+	if bytes.Count(p, []byte("\n")) <= n {
+		s.Out.Write(p)
+		return
+	}
+	if s.Pager != "" {
+		cmd := exec.Command("/bin/sh", "-c", s.Pager)
+		cmd.Stdin = bytes.NewReader(p)
+		cmd.Stdout = s.Out
+		cmd.Stderr = os.Stderr
+		if cmd.Run() == nil {
+			return
+		}
+	}
+	// Input that is not a terminal holds answers, not keys for the more
+	// prompt.
+	fd, ok := s.inputTerminal()
+	if !ok {
+		s.Out.Write(p)
+		return
+	}
+	for lines := n; len(p) > 0; {
+		for ; lines > 0 && len(p) > 0; lines-- {
+			i := bytes.IndexByte(p, '\n') + 1
+			if i == 0 {
+				i = len(p)
+			}
+			s.Out.Write(p[:i])
+			p = p[i:]
+		}
+		if len(p) == 0 {
+			return
+		}
+		switch s.more(fd) {
+		case ' ':
+			lines = n
+		case '\n':
+			lines = 1
+		default:
+			return
+		}
+	}
+}
+
+//  Display MorePrompt and wait for a key typed on the terminal fd. Returns
+//  ' ' to show the next page, '\n' to show the next line, or 'q' to stop.
+func (s *Session) more(fd uintptr) byte {
+	prompt := renderMarkup(MorePrompt, s.colorEnabled())
+	io.WriteString(s.Out, prompt)
+	ctx := context.Background()
+	restore, err := makeRaw(fd)
+	if err != nil {
+		io.WriteString(s.Out, "\n")
+		return 'q'
+	}
+	defer restore()
+	defer io.WriteString(s.Out, "\r\x1b[K")
+	for {
+		c, err := s.readByte(ctx)
+		if err != nil {
+			return 'q'
+		}
+		if c = moreKey(c); c != 0 {
+			return c
+		}
+	}
+}
+
+//  Interpret a key typed at the more prompt, or return 0 if it is not used.
+func moreKey(c byte) byte {
+	switch c {
+	case ' ', 'f':
+		return ' '
+	case '\r', '\n', 'j':
+		return '\n'
+	case 'q', 'Q', keyCtrlC, keyCtrlD:
+		return 'q'
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package goline
+/*
+ *  Filename:    page_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:00:38 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "strings"
+    "testing"
+)
+
+var pageItems = []string{"a", "b", "c", "d", "e", "f", "g"}
+
+func TestPageAt(T *testing.T) {
+    // Piped input is not consumed by the more prompt.
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("x\n"), out)
+    s.PageAt = 3
+    s.List(pageItems, Rows, nil)
+    if expect := "a\nb\nc\nd\ne\nf\ng\n"; out.String() != expect {
+        T.Errorf("Unexpected output %#v != %#v", out.String(), expect)
+    }
+    var x string
+    if err := s.Ask(&x, "? ", nil); err != nil || x != "x" {
+        T.Errorf("Unexpected answer %#v %v", x, err)
+    }
+}
+
+func TestMoreKey(T *testing.T) {
+    for c, expect := range map[byte]byte{
+        ' ': ' ', 'f': ' ', '\r': '\n', 'j': '\n', 'q': 'q', keyCtrlC: 'q', 'x': 0,
+    } {
+        if k := moreKey(c); k != expect {
+            T.Errorf("%q: Unexpected key %q", c, k)
+        }
+    }
+}
+
+func TestPager(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(""), out)
+    s.PageAt = 3
+    s.Pager = "tr a-z A-Z"
+    s.List(pageItems, Rows, nil)
+    if expect := "A\nB\nC\nD\nE\nF\nG\n"; out.String() != expect {
+        T.Errorf("Unexpected output %#v != %#v", out.String(), expect)
+    }
+}
+
+func TestChoosePaged(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("c\n"), out)
+    s.PageAt = 4
+    i, _, err := s.ChooseContext(context.Background(), func(m *Menu) {
+        m.Header = "Letters"
+        m.Question = "Letter? "
+        for _, c := range pageItems {
+            m.Choice(c, nil)
+        }
+    })
+    if err != nil || i != 2 {
+        T.Errorf("Unexpected choice %d %v", i, err)
+    }
+    if !strings.HasSuffix(out.String(), "6. g\nLetter? ") {
+        T.Errorf("Prompt displayed before the menu %#v", out.String())
+    }
+}
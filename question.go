@@ -66,9 +66,18 @@ const (
 	NoCompletion
 	// The error message printed when auto-completion is ambiguous.
 	AmbiguousCompletion
+	// A question that prompts the user to enter their answer again when the
+	// Question's Twice field is set.
+	Reenter
+	// The error message printed when the two answers to a Twice Question do
+	// not match.
+	Mismatch
+	// Introduces suggestions for an answer not in the Question's AnswerSet.
+	// See Question.ConfirmSuggestion.
+	DidYouMean
 )
 
-type Responses [6]string
+type Responses [9]string
 
 var defaultResponses = Responses{
 	AskOnError:  "Please retry:  ",
@@ -77,6 +86,9 @@ var defaultResponses = Responses{
 	//NotValid: "Invalid answer.",
 	NoCompletion:        "Unrecognized answer",
 	AmbiguousCompletion: "Ambiguous answer",
+	Reenter:             "Confirm:  ",
+	Mismatch:            "Answers do not match",
+	DidYouMean:          "Did you mean",
 }
 
 func makeResponses() Responses {
@@ -96,11 +108,16 @@ const (
 	Uint
 	Float
 	String
-	// Slice types are not implemented yet
+	// Slice types read a list of values separated by Question.Sep.
 	StringSlice
 	UintSlice
 	IntSlice
 	FloatSlice
+	// A type registered with RegisterType, or one whose pointer implements
+	// encoding.TextUnmarshaler or flag.Value.
+	Custom
+	// Boolean answers given as words. See BoolWords.
+	Bool
 )
 
 var tstring = []string{
@@ -112,10 +129,27 @@ var tstring = []string{
 	UintSlice:   "UintSlice",
 	IntSlice:    "IntSlice",
 	FloatSlice:  "FloatSlice",
+	Custom:      "Custom",
+	Bool:        "Bool",
+}
+
+var telem = []Type{
+	StringSlice: String,
+	UintSlice:   Uint,
+	IntSlice:    Int,
+	FloatSlice:  Float,
 }
 
 func (t Type) String() string    { return tstring[t] }
-func (t Type) IsSliceType() bool { return t >= StringSlice }
+func (t Type) IsSliceType() bool { return t >= StringSlice && t <= FloatSlice }
+
+//  The element type of a slice type. Other types are their own element type.
+func (t Type) Elem() Type {
+	if t.IsSliceType() {
+		return telem[t]
+	}
+	return t
+}
 
 func TypeOf(v interface{}) (typ Type, err error) {
 	switch v.(type) {
@@ -145,12 +179,61 @@ func TypeOf(v interface{}) (typ Type, err error) {
 		typ = Float
 	case string:
 		typ = String
+	case bool:
+		typ = Bool
 	default:
 		err = fmt.Errorf("Unrecognizable type %s", reflect.TypeOf(v).Name())
 	}
 	return
 }
 
+//  Vocabularies of words meaning true and false. The first word of each is
+//  used to display Default values.
+type BoolWords struct {
+	True, False []string
+}
+
+//  The words understood by Bool questions (and Confirm) unless a Question's
+//  BoolWords are changed.
+var DefaultBoolWords = BoolWords{
+	True:  []string{"yes", "y", "true", "t", "on", "1"},
+	False: []string{"no", "n", "false", "f", "off", "0"},
+}
+
+//  A string using notation `set {"yes", "y", ..., "no", ...}`
+func (words BoolWords) String() string {
+	all := make(StringSet, 0, len(words.True)+len(words.False))
+	return append(append(all, words.True...), words.False...).String()
+}
+
+//  Returns the value of word, which is compared to the vocabulary without
+//  regard to case. The ok result is false if word is not in the vocabulary.
+func (words BoolWords) Value(word string) (val, ok bool) {
+	for _, w := range words.True {
+		if strings.EqualFold(w, word) {
+			return true, true
+		}
+	}
+	for _, w := range words.False {
+		if strings.EqualFold(w, word) {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+//  Returns the word used to display x.
+func (words BoolWords) Word(x bool) string {
+	ws := words.False
+	if x {
+		ws = words.True
+	}
+	if len(ws) == 0 {
+		return fmt.Sprint(x)
+	}
+	return ws[0]
+}
+
 type Question struct {
 	// The "prompt" message for the user.
 	Question string
@@ -166,11 +249,41 @@ type Question struct {
 	FirstAnswer interface{}
 	// The default value used when the user inputs an empty string.
 	Default interface{}
-	// Separator for list (slice) input (TODO)
+	// Use Default as the answer when the deadline of the context given to
+	// AskContext passes before the user responds.
+	DefaultOnTimeout bool
+	// Separator for list (slice) input. An empty separator splits input on
+	// runs of whitespace.
 	Sep string
+	// Do not echo input as it is typed (e.g. for passwords). Echo is only
+	// controlled when In is a terminal, other input is never echoed. The
+	// buffers goline reads input into are zeroed after use.
+	Hidden bool
+	// If Hidden and Mask is not zero, Mask is echoed for each rune typed.
+	Mask rune
+	// Read the answer twice and require that both match (e.g. for new
+	// passwords). See the Reenter and Mismatch Responses.
+	Twice bool
+	// Read the answer with an interactive line editor if input is from a
+	// terminal. See LineEditor and Session.Edit.
+	Edit bool
+	// Identifies the question in the Session's History. Questions with the
+	// same ID share history entries. Defaults to the prompt.
+	ID string
+	// Do not record answers in, or recall them from, the Session's History.
+	// Answers to Hidden questions are never recorded.
+	NoHistory bool
+	// When an answer is not in the Question's AnswerSet but resembles one of
+	// its members, ask the user to confirm the closest member as the answer
+	// (see Suggest). Slice and Hidden questions are never confirmed.
+	ConfirmSuggestion bool
+	// Words accepted as answers to Bool questions.
+	BoolWords BoolWords
 	// Called when an error forces the prompt to halt without a value.
 	Panic func(error)
 	set   AnswerSet
+	check func(dest interface{}) error
+	conv  *converter
 	typ   Type
 	val   interface{}
 	def   interface{}
@@ -185,7 +298,12 @@ func newQuestion(t Type) *Question {
 	q.FirstAnswer = nil
 	switch q.typ {
 	case String:
+		fallthrough
+	case Custom:
+		q.Whitespace = Trim
+	case Bool:
 		q.Whitespace = Trim
+		q.BoolWords = DefaultBoolWords
 	case Int:
 		fallthrough
 	case Uint:
@@ -227,7 +345,12 @@ func (q *Question) setHas(x interface{}) bool {
 }
 
 func (q *Question) typeCast(v interface{}) (val interface{}, err error) {
-	switch q.typ {
+	return q.typeCastAs(q.typ, v)
+}
+
+//  Cast v to the wide (e.g. 64bit) representation of Type t.
+func (q *Question) typeCastAs(t Type, v interface{}) (val interface{}, err error) {
+	switch t {
 	case String:
 		switch v.(type) {
 		case string:
@@ -281,11 +404,50 @@ func (q *Question) typeCast(v interface{}) (val interface{}, err error) {
 	case UintSlice:
 		fallthrough
 	case FloatSlice:
-		err = fmt.Errorf("%s unimplemented", q.typ.String())
+		val, err = q.typeCastSlice(t, v)
+	case Custom:
+		val, err = q.typeCastCustom(v)
+	case Bool:
+		switch v.(type) {
+		case bool:
+			val = v
+		case string:
+			val, err = q.parseAs(Bool, v.(string))
+		default:
+			err = q.makeTypeError(true, v)
+		}
 	}
 	return
 }
 
+//  Cast a slice v to a slice of the wide element type of t ([]string,
+//  []int64, []uint64, or []float64).
+func (q *Question) typeCastSlice(t Type, v interface{}) (interface{}, error) {
+	wide := reflect.ValueOf(wideSlices[t])
+	sv := reflect.ValueOf(v)
+	if sv.Kind() != reflect.Slice {
+		return nil, q.makeTypeError(wide.Interface(), v)
+	}
+	n := sv.Len()
+	val := reflect.MakeSlice(wide.Type(), n, n)
+	for i := 0; i < n; i++ {
+		x, err := q.typeCastAs(t.Elem(), sv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		val.Index(i).Set(reflect.ValueOf(x))
+	}
+	return val.Interface(), nil
+}
+
+//  Empty slices of the wide types used to store slice values.
+var wideSlices = []interface{}{
+	StringSlice: []string{},
+	UintSlice:   []uint64{},
+	IntSlice:    []int64{},
+	FloatSlice:  []float64{},
+}
+
 func (q *Question) tryFirstAnswer() error {
 	if q.FirstAnswer != nil {
 		if val, err := q.typeCast(q.FirstAnswer); err != nil {
@@ -297,12 +459,29 @@ func (q *Question) tryFirstAnswer() error {
 	return nil
 }
 
-//  Return the a string representation of q.Default for the prompt.
+//  Return the a string representation of q.Default for the prompt. Slice
+//  defaults are joined by q.Sep.
 func (q *Question) defaultString(suffix string) string {
-	if q.Default != nil {
-		return fmt.Sprintf("|%v|%s", q.Default, suffix)
+	if q.Default == nil {
+		return ""
+	}
+	def := fmt.Sprint(q.Default)
+	switch x := q.Default.(type) {
+	case bool:
+		def = q.BoolWords.Word(x)
+	default:
+		if q.typ == Custom {
+			def = q.conv.formatAny(x)
+		}
 	}
-	return ""
+	if dv := reflect.ValueOf(q.Default); q.typ.IsSliceType() && dv.Kind() == reflect.Slice {
+		strs := make([]string, dv.Len())
+		for i := range strs {
+			strs[i] = fmt.Sprint(dv.Index(i).Interface())
+		}
+		def = strings.Join(strs, q.Sep)
+	}
+	return fmt.Sprintf("|%s|%s", def, suffix)
 }
 func (q *Question) tryDefault() (val interface{}, err error) {
 	val = nil
@@ -362,92 +541,138 @@ func (q *Question) parse(in string) error {
 
 	// Parse the user's input.
 	var val interface{}
-	switch q.typ {
-	case String:
-		if useDefault {
-			val = def.(string)
-		} else {
-			val = in
-		}
-	case Int:
-		var x int64
-		if useDefault {
-			x = def.(int64)
-		} else if noInput {
+	switch {
+	case useDefault:
+		val = def
+	case q.typ.IsSliceType():
+		if noInput && q.typ != StringSlice {
 			return ErrorEmptyInput
-		} else if x, err = strconv.ParseInt(in, 10, 64); err != nil {
-			return q.makeTypeError(x, in)
 		}
-		val = x
-	case Uint:
-		var x uint64
-		if useDefault {
-			x = def.(uint64)
-		} else if noInput {
-			return ErrorEmptyInput
-		} else if x, err = strconv.ParseUint(in, 10, 64); err != nil {
-			return q.makeTypeError(x, in)
+		if val, err = q.parseSlice(in); err != nil {
+			return err
 		}
-		val = x
-	case Float:
-		var x float64
-		if useDefault {
-			x = def.(float64)
-		} else if noInput {
-			return ErrorEmptyInput
-		} else if x, err = strconv.ParseFloat(in, 64); err != nil {
-			return q.makeTypeError(x, in)
+	case noInput && q.typ != String:
+		return ErrorEmptyInput
+	default:
+		if val, err = q.parseAs(q.typ, in); err != nil {
+			return err
 		}
-		val = x
-	case StringSlice:
-		fallthrough
-	case IntSlice:
-		fallthrough
-	case UintSlice:
-		fallthrough
-	case FloatSlice:
-		err = fmt.Errorf("%s unimplemented", q.typ.String())
 	}
 
-	// Check set membership
-	switch q.typ {
+	// Check set membership. Each element of a slice is checked separately.
+	if q.typ.IsSliceType() {
+		xs := reflect.ValueOf(val)
+		for i := 0; i < xs.Len(); i++ {
+			x, err := q.checkSet(xs.Index(i).Interface())
+			if err != nil {
+				return err
+			}
+			xs.Index(i).Set(reflect.ValueOf(x))
+		}
+	} else if val, err = q.checkSet(val); err != nil {
+		return err
+	}
+
+	// Set the parsed value if there was no error.
+	q.val = val
+	return nil
+}
+
+//  Parse a single value of Type t (not a slice type) from the input string.
+func (q *Question) parseAs(t Type, in string) (interface{}, error) {
+	switch t {
 	case String:
-		fallthrough
+		return in, nil
 	case Int:
-		fallthrough
+		x, err := strconv.ParseInt(in, 10, 64)
+		if err != nil {
+			return nil, q.makeTypeError(x, in)
+		}
+		return x, nil
 	case Uint:
-		fallthrough
+		x, err := strconv.ParseUint(in, 10, 64)
+		if err != nil {
+			return nil, q.makeTypeError(x, in)
+		}
+		return x, nil
 	case Float:
-		val, err = q.setComplete(val)
-		switch err.(type) {
-		case nil:
-			if !q.setHas(val) {
-				return q.makeErrorNotInSet(val)
-			}
-		case ErrorNoCompletion:
-			e := err.(ErrorNoCompletion)
-			e.Msg = q.Responses[NoCompletion]
-		case ErrorAmbiguousCompletion:
-			e := err.(ErrorAmbiguousCompletion)
-			e.Msg = q.Responses[AmbiguousCompletion]
-		default:
+		x, err := strconv.ParseFloat(in, 64)
+		if err != nil {
+			return nil, q.makeTypeError(x, in)
 		}
-	case StringSlice:
-		fallthrough
-	case IntSlice:
-		fallthrough
-	case UintSlice:
-		fallthrough
-	case FloatSlice:
-		err = fmt.Errorf("%s unimplemented", q.typ.String())
+		return x, nil
+	case Custom:
+		x, err := q.conv.parse(in)
+		if err != nil {
+			return nil, ErrorParse{q.conv.typ.String(), in, err}
+		}
+		return x, nil
+	case Bool:
+		x, ok := q.BoolWords.Value(in)
+		if !ok {
+			return nil, q.makeErrorNotInSet(q.BoolWords, in)
+		}
+		return x, nil
 	}
+	return nil, fmt.Errorf("Can not parse type %s", t.String())
+}
 
-	// Set the parsed value if there was no error.
-	if err == nil {
-		q.val = val
+//  Split the input string on q.Sep and parse each element. Elements are
+//  trimmed if q.Whitespace has the Trim option. An empty Sep splits the
+//  input on whitespace.
+func (q *Question) parseSlice(in string) (interface{}, error) {
+	var fields []string
+	switch {
+	case in == "":
+	case q.Sep == "":
+		fields = strings.Fields(in)
+	default:
+		fields = strings.Split(in, q.Sep)
 	}
+	t := q.typ.Elem()
+	val := reflect.MakeSlice(reflect.TypeOf(wideSlices[q.typ]), 0, len(fields))
+	for _, field := range fields {
+		if q.Whitespace&Trim > 0 {
+			field = strings.TrimSpace(field)
+		}
+		if field == "" && t != String {
+			return nil, ErrorEmptyInput
+		}
+		x, err := q.parseAs(t, field)
+		if err != nil {
+			return nil, err
+		}
+		val = reflect.Append(val, reflect.ValueOf(x))
+	}
+	return val.Interface(), nil
+}
 
-	return err
+//  Complete x if q.set is a CompletionSet, and check that the result is a
+//  member of q.set.
+func (q *Question) checkSet(x interface{}) (interface{}, error) {
+	answer := x
+	x, err := q.setComplete(x)
+	switch err.(type) {
+	case nil:
+		if !q.setHas(x) {
+			return nil, q.makeErrorNotInSet(q.set, x)
+		}
+	case ErrorNoCompletion:
+		e := err.(ErrorNoCompletion)
+		e.Msg = q.Responses[NoCompletion]
+		if e.Suggestions = suggestions(q.set, answer); len(e.Suggestions) > 0 {
+			// Suggestions replace the whole answer (e.g. keeping the
+			// arguments of shell commands).
+			e.Value = answer
+			e.DidYouMean = q.Responses[DidYouMean]
+		}
+		err = e
+	case ErrorAmbiguousCompletion:
+		e := err.(ErrorAmbiguousCompletion)
+		e.Msg = q.Responses[AmbiguousCompletion]
+		err = e
+	}
+	return x, err
 }
 
 // Cast a value result from a wide (e.g. 64bit) type to the desired type.
@@ -537,6 +762,67 @@ func (q *Question) setDest(dest interface{}) error {
 		default:
 			return fmt.Errorf("Unexpected cast type")
 		}
+	case StringSlice:
+		fallthrough
+	case IntSlice:
+		fallthrough
+	case UintSlice:
+		fallthrough
+	case FloatSlice:
+		return q.setDestSlice(dest)
+	case Custom:
+		dv := reflect.ValueOf(dest)
+		if dv.Kind() != reflect.Ptr || dv.Elem().Type() != q.conv.typ {
+			return fmt.Errorf("Unexpected cast type")
+		}
+		dv.Elem().Set(reflect.ValueOf(q.val))
+	case Bool:
+		switch dest.(type) {
+		case *bool:
+			*(dest.(*bool)) = q.val.(bool)
+		default:
+			return fmt.Errorf("Unexpected cast type")
+		}
+	}
+	return nil
+}
+
+//  Set the destination after checking the value with any validity test that
+//  must be applied to the destination type (see AskT). The value is cast into
+//  a copy of the destination first, so dest is unchanged when it is rejected.
+func (q *Question) assign(dest interface{}) error {
+	tmp := reflect.New(reflect.TypeOf(dest).Elem())
+	if err := q.setDest(tmp.Interface()); err != nil {
+		return err
+	}
+	if q.check != nil {
+		if err := q.check(tmp.Interface()); err != nil {
+			return err
+		}
+	}
+	reflect.ValueOf(dest).Elem().Set(tmp.Elem())
+	return nil
+}
+
+//  Copy the wide slice value into the slice pointed to by dest, casting each
+//  element to the destination's element type.
+func (q *Question) setDestSlice(dest interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("Unexpected cast type")
+	}
+	typ := dv.Elem().Type()
+	src := reflect.ValueOf(q.val)
+	n := src.Len()
+	val := reflect.MakeSlice(typ, n, n)
+	for i := 0; i < n; i++ {
+		wide := src.Index(i)
+		thin := wide.Convert(typ.Elem())
+		if thin.Convert(wide.Type()).Interface() != wide.Interface() {
+			return ErrorPrecision{wide.Interface(), thin.Interface()}
+		}
+		val.Index(i).Set(thin)
 	}
+	dv.Elem().Set(val)
 	return nil
 }
@@ -7,6 +7,7 @@ package goline
  *  Usage:       gotest
  */
 import (
+    "reflect"
     "testing"
 )
 
@@ -105,3 +106,79 @@ func TestQuestionString(T *testing.T) {
     testGood(T, q, "In set", "def", "def")
     testBad(T, q, "Not in set", "blah")
 }
+
+func TestQuestionBool(T *testing.T) {
+    q := newQuestion(Bool)
+    testGood(T, q, "Yes", "yes", true)
+    testGood(T, q, "Upper", " YES ", true)
+    testGood(T, q, "True", "true", true)
+    testGood(T, q, "On", "On", true)
+    testGood(T, q, "One", "1", true)
+    testGood(T, q, "No", "n", false)
+    testGood(T, q, "Off", "off", false)
+    testBad(T, q, "Maybe", "maybe")
+    testBad(T, q, "Empty", "")
+    q.Default = false
+    testGood(T, q, "Default", "", false)
+    if s := q.defaultString(" "); s != "|no| " {
+        T.Errorf("Unexpected default string %#v", s)
+    }
+    q.BoolWords = BoolWords{True: []string{"oui"}, False: []string{"non"}}
+    testGood(T, q, "Custom", "OUI", true)
+    testBad(T, q, "Not custom", "yes")
+}
+
+func testGoodSlice(T *testing.T, q *Question, name, in string, v interface{}) error {
+    t := q.Type()
+    err := q.parse(in)
+    if err != nil {
+        T.Errorf("%s %s parse failed %s", name, t.String(), err.Error())
+    } else if !reflect.DeepEqual(q.val, v) {
+        T.Errorf("Parsed value != expected value (%#v != %#v)", q.val, v)
+    }
+    return err
+}
+
+func TestQuestionIntSlice(T *testing.T) {
+    q := newQuestion(IntSlice)
+    testGoodSlice(T, q, "Simple", "1 2  -3", []int64{1, 2, -3})
+    testBad(T, q, "Bad element", "1 2 x")
+    testBad(T, q, "Empty", "")
+    q.Sep = ","
+    testGoodSlice(T, q, "Comma", "1, 2 ,3", []int64{1, 2, 3})
+    testBad(T, q, "Empty element", "1,,3")
+    q.In(IntRange{0, 5})
+    testGoodSlice(T, q, "In-range", "0,5", []int64{0, 5})
+    testBad(T, q, "Out of range", "0,6")
+    q.Default = []int{4, 5}
+    testGoodSlice(T, q, "Default", "", []int64{4, 5})
+    if s := q.defaultString(" "); s != "|4,5| " {
+        T.Errorf("Unexpected default string %#v", s)
+    }
+}
+
+func TestQuestionStringSlice(T *testing.T) {
+    q := newQuestion(StringSlice)
+    testGoodSlice(T, q, "Simple", " a b c ", []string{"a", "b", "c"})
+    testGoodSlice(T, q, "Empty", "", []string{})
+    q.In(StringCompletionSet{"apple", "banana"})
+    testGoodSlice(T, q, "Completed", "ap ban", []string{"apple", "banana"})
+    testBad(T, q, "Not completed", "ap cherry")
+}
+
+func TestQuestionSetDestSlice(T *testing.T) {
+    q := newQuestion(IntSlice)
+    var small []int8
+    if err := q.parse("1 2 3"); err != nil {
+        T.Fatalf("Parse failed %s", err.Error())
+    }
+    if err := q.setDest(&small); err != nil || !reflect.DeepEqual(small, []int8{1, 2, 3}) {
+        T.Errorf("Unexpected destination %#v (%v)", small, err)
+    }
+    if err := q.parse("1 2 300"); err != nil {
+        T.Fatalf("Parse failed %s", err.Error())
+    }
+    if err := q.setDest(&small); err == nil {
+        T.Errorf("Precision loss not detected %#v", small)
+    }
+}
@@ -0,0 +1,137 @@
+package goline
+
+/*
+ *  Filename:    registry.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:44:38 UTC 2026
+ *  Description: A registry of parsers and formatters for custom answer types.
+ */
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+)
+
+//  Functions converting between input strings and values of a Custom type.
+type converter struct {
+	typ    reflect.Type
+	parse  func(string) (interface{}, error)
+	format func(interface{}) string
+}
+
+var registry = struct {
+	sync.RWMutex
+	m map[reflect.Type]*converter
+}{m: make(map[reflect.Type]*converter)}
+
+var (
+	typeTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	typeFlagValue       = reflect.TypeOf((*flag.Value)(nil)).Elem()
+)
+
+func init() {
+	Register(time.ParseDuration, time.Duration.String)
+	Register(func(s string) (url.URL, error) {
+		u, err := url.Parse(s)
+		if err != nil {
+			return url.URL{}, err
+		}
+		return *u, nil
+	}, func(u url.URL) string { return u.String() })
+}
+
+//  Register a parser and a formatter for answers of type typ. Ask will then
+//  accept destinations of type *typ. The parser is given the input after the
+//  Question's Whitespace and Case preprocessing and must return a value of
+//  type typ. Values it returns are checked against the Question's AnswerSet.
+//  The formatter is used to display Default values, if it is nil values are
+//  formatted with their String or MarshalText methods, or with fmt.Sprint.
+//
+//  Types whose pointers implement encoding.TextUnmarshaler or flag.Value
+//  (e.g. net.IP, time.Time) need not be registered. The time.Duration and
+//  url.URL types are registered by default.
+func RegisterType(typ reflect.Type, parse func(string) (interface{}, error), format func(interface{}) string) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.m[typ] = &converter{typ, parse, format}
+}
+
+//  A type-safe wrapper around RegisterType.
+//      goline.Register(strconv.ParseBool, strconv.FormatBool)
+func Register[T any](parse func(string) (T, error), format func(T) string) {
+	var f func(interface{}) string
+	if format != nil {
+		f = func(v interface{}) string { return format(v.(T)) }
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	RegisterType(typ, func(s string) (interface{}, error) { return parse(s) }, f)
+}
+
+//  Returns a converter for values of type typ, or nil if there is none.
+func converterFor(typ reflect.Type) *converter {
+	registry.RLock()
+	conv := registry.m[typ]
+	registry.RUnlock()
+	if conv != nil {
+		return conv
+	}
+	switch ptr := reflect.PointerTo(typ); {
+	case ptr.Implements(typeTextUnmarshaler):
+		return &converter{typ: typ, parse: func(s string) (interface{}, error) {
+			p := reflect.New(typ)
+			err := p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			return p.Elem().Interface(), err
+		}}
+	case ptr.Implements(typeFlagValue):
+		return &converter{typ: typ, parse: func(s string) (interface{}, error) {
+			p := reflect.New(typ)
+			err := p.Interface().(flag.Value).Set(s)
+			return p.Elem().Interface(), err
+		}}
+	}
+	return nil
+}
+
+//  Format a value of the converter's type. Methods with pointer receivers
+//  are used if necessary.
+func (conv *converter) formatValue(v interface{}) string {
+	if conv.format != nil {
+		return conv.format(v)
+	}
+	p := reflect.New(conv.typ)
+	p.Elem().Set(reflect.ValueOf(v))
+	switch x := p.Interface().(type) {
+	case encoding.TextMarshaler:
+		if text, err := x.MarshalText(); err == nil {
+			return string(text)
+		}
+	case fmt.Stringer:
+		return x.String()
+	}
+	return fmt.Sprint(v)
+}
+
+//  Format v if it has the converter's type. Otherwise v is formatted with
+//  fmt.Sprint.
+func (conv *converter) formatAny(v interface{}) string {
+	if reflect.TypeOf(v) == conv.typ {
+		return conv.formatValue(v)
+	}
+	return fmt.Sprint(v)
+}
+
+//  Cast v to the Question's Custom type. Strings are parsed.
+func (q *Question) typeCastCustom(v interface{}) (interface{}, error) {
+	if s, ok := v.(string); ok {
+		return q.parseAs(Custom, s)
+	}
+	if reflect.TypeOf(v) != q.conv.typ {
+		return nil, q.makeTypeError(reflect.Zero(q.conv.typ).Interface(), v)
+	}
+	return v, nil
+}
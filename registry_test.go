@@ -0,0 +1,91 @@
+package goline
+/*
+ *  Filename:    registry_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:44:38 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "errors"
+    "net"
+    "net/url"
+    "strings"
+    "testing"
+    "time"
+)
+
+type testLevel int
+
+func (l *testLevel) String() string { return [...]string{"low", "high"}[*l] }
+func (l *testLevel) Set(s string) error {
+    switch s {
+    case "low":
+        *l = 0
+    case "high":
+        *l = 1
+    default:
+        return errors.New("unknown level")
+    }
+    return nil
+}
+
+type testPoint struct{ X, Y int }
+
+func TestAskDuration(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("soon\n\n"), out)
+    var d time.Duration
+    err := s.Ask(&d, "Timeout? ", func(q *Question) { q.Default = 90 * time.Second })
+    if err != nil || d != 90*time.Second {
+        T.Errorf("Unexpected answer %v %v", d, err)
+    }
+    if !strings.HasPrefix(out.String(), "Timeout? |1m30s| Error: Invalid time.Duration") {
+        T.Errorf("Unexpected output %#v", out.String())
+    }
+}
+
+func TestAskTextUnmarshaler(T *testing.T) {
+    s := NewSession(strings.NewReader("  10.0.0.1 \n"), new(bytes.Buffer))
+    var ip net.IP
+    if err := s.Ask(&ip, "Address? ", nil); err != nil || !ip.Equal(net.IPv4(10, 0, 0, 1)) {
+        T.Errorf("Unexpected answer %v %v", ip, err)
+    }
+}
+
+func TestAskURL(T *testing.T) {
+    s := NewSession(strings.NewReader("https://example.com/x\n"), new(bytes.Buffer))
+    var u url.URL
+    if err := s.Ask(&u, "URL? ", nil); err != nil || u.Host != "example.com" {
+        T.Errorf("Unexpected answer %v %v", u, err)
+    }
+}
+
+func TestAskFlagValue(T *testing.T) {
+    s := NewSession(strings.NewReader("HIGH\n"), new(bytes.Buffer))
+    var l testLevel
+    err := s.Ask(&l, "Level? ", func(q *Question) { q.Case = Lower })
+    if err != nil || l != 1 {
+        T.Errorf("Unexpected answer %v %v", l, err)
+    }
+}
+
+func TestAskRegistered(T *testing.T) {
+    Register(func(s string) (testPoint, error) {
+        var p testPoint
+        fields := strings.Split(s, ",")
+        if len(fields) != 2 {
+            return p, errors.New("expected x,y")
+        }
+        p.X, p.Y = len(fields[0]), len(fields[1])
+        return p, nil
+    }, nil)
+    s := NewSession(strings.NewReader("a\naa,a\n"), new(bytes.Buffer))
+    p, err := SessionAskT(s, context.Background(), "Point? ",
+        WithSet[testPoint](SetOf[testPoint]{{2, 1}}))
+    if err != nil || p != (testPoint{2, 1}) {
+        T.Errorf("Unexpected answer %v %v", p, err)
+    }
+}
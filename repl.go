@@ -0,0 +1,161 @@
+package goline
+
+/*
+ *  Filename:    repl.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:13:41 UTC 2026
+ *  Description: A read-eval-print loop running the commands of a Shell menu.
+ */
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//  The names of the commands REPL adds to menus that do not define them.
+var (
+	CommandHelp = "help"
+	CommandQuit = "quit"
+	CommandExit = "exit"
+)
+
+//  Returned by a command (see Menu.Command) to end a REPL without error.
+var ErrorStop = NewError("Stop")
+
+//  Describe the choice named name. Descriptions are listed by the help
+//  command of a REPL.
+func (m *Menu) Describe(name interface{}, desc string) {
+	if m.Descriptions == nil {
+		m.Descriptions = make(map[string]string)
+	}
+	m.Descriptions[makeStringer(name).String()] = desc
+}
+
+//  Repeatedly prompt the user for commands of a Shell menu configured by
+//  config, and run them, until a command returns an error. The menu is
+//  configured again for each command. Its header and choices are displayed
+//  only before the first prompt. REPL adds these commands unless the menu
+//  defines them:
+//      help [COMMAND]  list the commands (or describe one, see Menu.Describe)
+//      quit, exit      end the REPL
+//  A command may return ErrorStop to end the REPL. Recoverable errors are
+//  printed and the user is prompted again (see Menu.Command), as are
+//  interrupted prompts (Ctrl-C). The end of input (Ctrl-D) ends the REPL.
+//  Other errors are returned, and errors wrapping ErrorStop, io.EOF or
+//  ErrorInterrupt are treated like them. Input is read with the line editor
+//  when In is a terminal, so previous commands are recalled from the
+//  Session's History (or, if it has none, a History kept in memory for the
+//  duration of the REPL).
+//      goline.REPL(func(m *goline.Menu) {
+//          m.Question = "> "
+//          m.Command("echo", func(argv []string) error {
+//              goline.Say(strings.Join(argv[1:], " ") + "\n")
+//              return nil
+//          })
+//          m.Describe("echo", "print the arguments")
+//      })
+func REPL(config func(*Menu)) error { return DefaultSession.REPL(config) }
+
+//  Like REPL, but the loop ends when ctx is done, returning an ErrorCanceled.
+func REPLContext(ctx context.Context, config func(*Menu)) error {
+	return DefaultSession.REPLContext(ctx, config)
+}
+
+//  Like REPL, but output is written to s.Out and input is read from s.In.
+func (s *Session) REPL(config func(*Menu)) error {
+	return s.REPLContext(context.Background(), config)
+}
+
+//  Like REPLContext, but output is written to s.Out and input is read from
+//  s.In.
+func (s *Session) REPLContext(ctx context.Context, config func(*Menu)) error {
+	if s.History == nil {
+		s.History = NewHistory("", 0)
+		defer func() { s.History = nil }()
+	}
+	for listed := false; ; listed = true {
+		_, _, _, err := s.choose(ctx, func(m *Menu) {
+			config(m)
+			m.Shell = true
+			m.edit = true
+			m.unlisted = listed
+			s.replCommands(m)
+		})
+		switch {
+		case err == nil, errors.Is(err, ErrorInterrupt):
+		case errors.Is(err, ErrorStop), errors.Is(err, io.EOF):
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+//  Add the built-in REPL commands not defined by m.
+func (s *Session) replCommands(m *Menu) {
+	defined := make(map[string]bool, m.Len())
+	for _, c := range m.Choices {
+		defined[c.String()] = true
+	}
+	if !defined[CommandHelp] {
+		m.Command(CommandHelp, func(argv []string) error { return s.replHelp(m, argv[1:]) })
+		m.Describe(CommandHelp, "list the commands, or describe one")
+	}
+	stop := func(argv []string) error { return ErrorStop }
+	for _, name := range []string{CommandQuit, CommandExit} {
+		if !defined[name] {
+			m.Command(name, stop)
+			m.Describe(name, "end the session")
+		}
+	}
+}
+
+//  List the commands of m with their descriptions, or describe the commands
+//  named by args.
+func (s *Session) replHelp(m *Menu, args []string) error {
+	if len(args) == 0 {
+		width := 0
+		for _, c := range m.Choices {
+			if w := StringWidth(c.String()); w > width {
+				width = w
+			}
+		}
+		s.page(func() {
+			for _, c := range m.Choices {
+				line := padRight(c.String(), width) + "  " + m.Descriptions[c.String()]
+				s.Say(EscapeMarkup(strings.TrimRight(line, " ")) + "\n")
+			}
+		})
+		return nil
+	}
+	for _, name := range args {
+		i := m.index(name)
+		if i < 0 {
+			return NewErrorRecoverable(fmt.Sprintf("Unknown command %q", name))
+		}
+		usage := name
+		if cmd, ok := m.Choices[i].(commandChoice); ok && cmd.usage != "" {
+			usage = cmd.usage
+		}
+		s.Say(EscapeMarkup("usage: "+usage) + "\n")
+		if desc := m.Descriptions[name]; desc != "" {
+			s.Indent()
+			s.Say(EscapeMarkup(desc) + "\n")
+			s.Outdent()
+		}
+	}
+	return nil
+}
+
+//  The index of the choice named name, or -1 if there is none.
+func (m *Menu) index(name string) int {
+	for i, c := range m.Choices {
+		if c.String() == name {
+			return i
+		}
+	}
+	return -1
+}
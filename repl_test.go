@@ -0,0 +1,116 @@
+package goline
+/*
+ *  Filename:    repl_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:13:41 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "errors"
+    "flag"
+    "fmt"
+    "io"
+    "strings"
+    "testing"
+)
+
+func testREPL(input string, config func(*Menu)) (string, error) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(input), out)
+    err := s.REPL(func(m *Menu) {
+        m.Header = "Commands"
+        m.Question = "> "
+        m.ListMode = Inline
+        m.IndexMode = NoIndex
+        config(m)
+    })
+    return out.String(), err
+}
+
+func TestREPL(T *testing.T) {
+    var echoed []string
+    config := func(m *Menu) {
+        m.Command("echo", func(argv []string) error {
+            echoed = append(echoed, strings.Join(argv[1:], " "))
+            return nil
+        })
+        m.Describe("echo", "print the arguments")
+        fs := flag.NewFlagSet("greet", flag.ContinueOnError)
+        fs.Bool("loud", false, "shout")
+        m.CommandFlags("greet", fs, "NAME", func(fs *flag.FlagSet) error { return nil })
+    }
+    out, err := testREPL("echo a 'b c'\nhelp\nhelp greet\necho d\nquit\necho e\n", config)
+    if err != nil {
+        T.Fatalf("Unexpected error %v", err)
+    }
+    if expect := []string{"a b c", "d"}; strings.Join(echoed, "|") != strings.Join(expect, "|") {
+        T.Errorf("Unexpected commands %#v", echoed)
+    }
+    if strings.Count(out, "Commands") != 1 {
+        T.Errorf("Menu not listed once %#v", out)
+    }
+    for _, msg := range []string{
+        "echo   print the arguments\n",
+        "help   list the commands, or describe one\n",
+        "exit   end the session\n",
+        "usage: greet [-loud] NAME\n",
+    } {
+        if !strings.Contains(out, msg) {
+            T.Errorf("%#v not printed %#v", msg, out)
+        }
+    }
+
+    echoed = nil
+    if _, err = testREPL("echo a\n", config); err != nil || len(echoed) != 1 {
+        T.Errorf("Unexpected end of input %v %#v", err, echoed)
+    }
+    out, err = testREPL("help nope\nexit\n", config)
+    if err != nil || !strings.Contains(out, `Unknown command "nope"`) {
+        T.Errorf("Unexpected help %v %#v", err, out)
+    }
+}
+
+func TestREPLStop(T *testing.T) {
+    fail := errors.New("fail")
+    _, err := testREPL("stop\n", func(m *Menu) {
+        m.Command("stop", func(argv []string) error { return ErrorStop })
+    })
+    if err != nil {
+        T.Errorf("Unexpected error %v", err)
+    }
+    _, err = testREPL("stop\nfail\n", func(m *Menu) {
+        m.Command("stop", func(argv []string) error { return fmt.Errorf("done: %w", ErrorStop) })
+        m.Command("fail", func(argv []string) error { return fail })
+    })
+    if err != nil {
+        T.Errorf("Wrapped ErrorStop not recognized %v", err)
+    }
+    _, err = testREPL("eof\n", func(m *Menu) {
+        m.Command("eof", func(argv []string) error { return fmt.Errorf("nested: %w", io.EOF) })
+    })
+    if err != nil {
+        T.Errorf("Wrapped io.EOF not recognized %v", err)
+    }
+    _, err = testREPL("intr\nfail\n", func(m *Menu) {
+        m.Command("intr", func(argv []string) error { return fmt.Errorf("nested: %w", ErrorInterrupt) })
+        m.Command("fail", func(argv []string) error { return fail })
+    })
+    if err != fail {
+        T.Errorf("Wrapped ErrorInterrupt ended the REPL %v", err)
+    }
+    _, err = testREPL("fail\nquit\n", func(m *Menu) {
+        m.Command("fail", func(argv []string) error { return fail })
+    })
+    if err != fail {
+        T.Errorf("Unexpected error %v", err)
+    }
+    quit := false
+    _, err = testREPL("quit\nexit\n", func(m *Menu) {
+        m.Choice("quit", func(name, args string) { quit = true })
+    })
+    if err != nil || !quit {
+        T.Errorf("Built-in quit not replaced %v %v", quit, err)
+    }
+}
@@ -0,0 +1,161 @@
+package goline
+
+/*
+ *  Filename:    select.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:03:29 UTC 2026
+ *  Description: Menus answered by moving a highlight with the arrow keys.
+ */
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//  Marks the highlighted choice of an Interactive menu.
+var SelectMarker = "> "
+
+//  Returns the terminal file descriptor of s.In if m should be answered
+//  interactively (see Menu.Interactive and Menu.Filter).
+func (s *Session) selectTerminal(m *Menu) (uintptr, bool) {
+	if !m.Interactive && !m.Filter {
+		return 0, false
+	}
+	fd, ok := s.inputTerminal()
+	if !ok {
+		return 0, false
+	}
+	if _, ok := s.outputTerminal(); !ok {
+		return 0, false
+	}
+	return fd, true
+}
+
+//  The state of an Interactive menu.
+type menuSelect struct {
+	s      *Session
+	items  []string
+	cur    int
+	top    int
+	rows   int
+	drawn  int
+	colors bool
+}
+
+//  The number of choices displayed at once.
+func (s *Session) viewport(m *Menu) int {
+	rows := m.Viewport
+	if rows <= 0 {
+		// Leave room for the header and the chosen item.
+		rows = s.Height() - 2
+		if m.Header != "" {
+			rows -= strings.Count(m.Header, "\n") + 1
+		}
+	}
+	if rows > m.Len() {
+		rows = m.Len()
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+//  Let the user choose from items (the rendered choices of m) with the arrow
+//  keys. The terminal fd is put in raw mode.
+func (s *Session) selectChoice(ctx context.Context, fd uintptr, m *Menu, items []string) (int, error) {
+	restore, err := makeRaw(fd)
+	if err != nil {
+		return -1, err
+	}
+	defer restore()
+	return s.runSelect(ctx, m, items)
+}
+
+//  Run an Interactive menu on a terminal already in raw mode.
+func (s *Session) runSelect(ctx context.Context, m *Menu, items []string) (int, error) {
+	if len(m.Header) > 0 {
+		s.Say(m.Header)
+	}
+	sel := &menuSelect{s: s, items: items, rows: s.viewport(m), colors: s.colorEnabled()}
+	sel.draw()
+	for {
+		key, err := s.readKey(ctx)
+		if err != nil {
+			io.WriteString(s.Out, "\n")
+			return -1, err
+		}
+		switch key {
+		case "\r", "\n":
+			io.WriteString(s.Out, "\n")
+			s.Say(m.Question + EscapeMarkup(m.Choices[sel.cur].String()) + "\n")
+			return sel.cur, nil
+		case "\x03", "q":
+			io.WriteString(s.Out, "\n")
+			return -1, ErrorInterrupt
+		case "\x04":
+			io.WriteString(s.Out, "\n")
+			return -1, io.EOF
+		case "\x1b[A", "\x1bOA", "k", "\x10":
+			sel.move(sel.cur - 1)
+		case "\x1b[B", "\x1bOB", "j", "\x0e":
+			sel.move(sel.cur + 1)
+		case "\x1b[5~":
+			sel.move(sel.cur - sel.rows)
+		case "\x1b[6~", " ":
+			sel.move(sel.cur + sel.rows)
+		case "\x1b[H", "\x1bOH", "\x1b[1~", "g":
+			sel.move(0)
+		case "\x1b[F", "\x1bOF", "\x1b[4~", "G":
+			sel.move(len(items) - 1)
+		default:
+			continue
+		}
+		sel.draw()
+	}
+}
+
+//  Highlight the item at index i, scrolling the viewport to show it.
+func (sel *menuSelect) move(i int) {
+	switch {
+	case i < 0:
+		i = 0
+	case i >= len(sel.items):
+		i = len(sel.items) - 1
+	}
+	sel.cur = i
+	if i < sel.top {
+		sel.top = i
+	} else if i >= sel.top+sel.rows {
+		sel.top = i - sel.rows + 1
+	}
+}
+
+//  Redraw the viewport. The cursor is left at the end of its last line.
+func (sel *menuSelect) draw() {
+	var b strings.Builder
+	if sel.drawn > 1 {
+		fmt.Fprintf(&b, "\x1b[%dA", sel.drawn-1)
+	}
+	b.WriteString("\r")
+	width := sel.s.textWidth()
+	blank := strings.Repeat(" ", StringWidth(SelectMarker))
+	for i := sel.top; i < sel.top+sel.rows; i++ {
+		if i > sel.top {
+			b.WriteString("\n")
+		}
+		line := blank + sel.items[i]
+		if i == sel.cur {
+			line = SelectMarker + sel.items[i]
+		}
+		line = truncate(line, width-1, "…")
+		if i == sel.cur && sel.colors {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+		b.WriteString(sel.s.indentation() + line + "\x1b[K")
+	}
+	sel.drawn = sel.rows
+	io.WriteString(sel.s.Out, b.String())
+}
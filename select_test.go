@@ -0,0 +1,78 @@
+package goline
+/*
+ *  Filename:    select_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:03:29 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "io"
+    "strings"
+    "testing"
+)
+
+func testSelect(keys string, viewport int) (int, string, error) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(keys), out)
+    m := newMenu()
+    m.Question = "Fruit? "
+    m.Viewport = viewport
+    for _, c := range []string{"apple", "banana", "cherry", "date"} {
+        m.Choice(c, nil)
+    }
+    items, _, _ := m.Selections()
+    i, err := s.runSelect(context.Background(), m, items)
+    return i, out.String(), err
+}
+
+func TestSelectChoice(T *testing.T) {
+    for _, test := range []struct {
+        keys   string
+        expect int
+    }{
+        {"\r", 0},
+        {"\x1b[B\x1b[Bk\r", 1},
+        {"jjjjjj\r", 3},
+        {"G\x1b[A\r", 2},
+        {"\x1b[6~g\x1b[C\r", 0},
+    } {
+        if i, _, err := testSelect(test.keys, 0); err != nil || i != test.expect {
+            T.Errorf("%#v: Unexpected choice %d %v", test.keys, i, err)
+        }
+    }
+    if _, _, err := testSelect("jq", 0); err != ErrorInterrupt {
+        T.Errorf("Unexpected error %v", err)
+    }
+    if _, _, err := testSelect("j", 0); err != io.EOF {
+        T.Errorf("Unexpected error %v", err)
+    }
+}
+
+func TestSelectViewport(T *testing.T) {
+    i, out, err := testSelect("jj\r", 2)
+    if err != nil || i != 2 {
+        T.Fatalf("Unexpected choice %d %v", i, err)
+    }
+    expect := "\r> 0. apple\x1b[K\n  1. banana\x1b[K" +
+        "\x1b[1A\r  0. apple\x1b[K\n> 1. banana\x1b[K" +
+        "\x1b[1A\r  1. banana\x1b[K\n> 2. cherry\x1b[K" +
+        "\nFruit? cherry\n"
+    if out != expect {
+        T.Errorf("Unexpected output %#v != %#v", out, expect)
+    }
+}
+
+func TestSelectFallback(T *testing.T) {
+    s := NewSession(strings.NewReader("banana\n"), new(bytes.Buffer))
+    i, _ := s.Choose(func(m *Menu) {
+        m.Interactive = true
+        m.Choice("apple", nil)
+        m.Choice("banana", nil)
+    })
+    if i != 1 {
+        T.Errorf("Unexpected choice %d", i)
+    }
+}
@@ -0,0 +1,192 @@
+package goline
+
+/*
+ *  Filename:    session.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:40:11 UTC 2026
+ *  Description: Prompting sessions bound to arbitrary input/output streams.
+ */
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+)
+
+//  A Session reads user input from In and writes messages and prompts to
+//  Out. Its methods mirror the package-level functions (Say, List, Ask,
+//  Confirm, Choose, ...), which use DefaultSession. Any io.Reader and
+//  io.Writer can be used, so a Session can be driven over a socket, a pty,
+//  or an in-memory buffer.
+//      in := strings.NewReader("42\n")
+//      s := goline.NewSession(in, os.Stdout)
+//      var x int
+//      s.Ask(&x, "Answer? ", nil)
+//  Input read from In is buffered by the Session and kept between calls. A
+//  Session should not be used from multiple goroutines concurrently.
+type Session struct {
+	// The source of user input.
+	In io.Reader
+	// The destination of messages and prompts.
+	Out io.Writer
+	// Read answers with an interactive line editor when In is a terminal.
+	// See LineEditor. This sets the default for each Question's Edit field.
+	Edit bool
+	// The key bindings of the line editor. If nil, DefaultKeymap is used.
+	Keymap Keymap
+	// Answers recalled by the line editor. If nil (the default), answers are
+	// not recorded. See NewHistory.
+	History *History
+	// Whether style markup in messages is rendered as ANSI escape sequences.
+	// See Style.
+	Color ColorMode
+	// The width messages printed by Say are wrapped at, or WrapAuto. If not
+	// positive (the default), messages are not wrapped.
+	WrapAt int
+	// The number of spaces per indentation level. See Session.Indent.
+	IndentSize int
+	// The number of lines of output shown before pausing at MorePrompt, or
+	// PageAuto. If zero (the default), output is not paged.
+	PageAt int
+	// A shell command (e.g. os.Getenv("PAGER")) that output longer than
+	// PageAt lines is piped through instead of pausing at MorePrompt.
+	Pager   string
+	indent  int
+	paging  *bytes.Buffer
+	r       *inputBuffer
+	rin     io.Reader
+	pending chan readResult
+}
+
+//  The result of a single byte read from a Session's input.
+type readResult struct {
+	c   byte
+	err error
+}
+
+//  The Session used by the package-level functions. It is bound to os.Stdin
+//  and os.Stdout.
+var DefaultSession = NewSession(os.Stdin, os.Stdout)
+
+//  Create a new Session reading from in and writing to out.
+func NewSession(in io.Reader, out io.Writer) *Session {
+	return &Session{In: in, Out: out}
+}
+
+//  A buffered reader. Unlike a bufio.Reader, input that has been consumed can
+//  be wiped from its buffer.
+type inputBuffer struct {
+	rd   io.Reader
+	buf  []byte
+	r, w int
+	err  error
+}
+
+func newInputBuffer(rd io.Reader) *inputBuffer {
+	return &inputBuffer{rd: rd, buf: make([]byte, 4096)}
+}
+
+//  The number of bytes that can be read without reading from the underlying
+//  io.Reader.
+func (b *inputBuffer) Buffered() int { return b.w - b.r }
+
+func (b *inputBuffer) ReadByte() (byte, error) {
+	for b.r == b.w {
+		if b.err != nil {
+			err := b.err
+			b.err = nil
+			return 0, err
+		}
+		// The buffer is only filled when it is empty.
+		n, err := b.rd.Read(b.buf)
+		b.r, b.w, b.err = 0, n, err
+	}
+	c := b.buf[b.r]
+	b.r++
+	return c, nil
+}
+
+//  Overwrite all consumed input in the buffer with zeros.
+func (b *inputBuffer) wipe() { wipeBytes(b.buf[:b.r]) }
+
+func wipeBytes(p []byte) {
+	for i := range p {
+		p[i] = 0
+	}
+}
+
+//  Wipe the line p and all consumed input from the Session's buffer.
+func (s *Session) wipeInput(p []byte) {
+	wipeBytes(p)
+	if s.pending == nil && s.r != nil {
+		s.r.wipe()
+	}
+}
+
+//  Returns the buffered reader for s.In. The buffer is only replaced if s.In
+//  has been reassigned since the last call.
+func (s *Session) reader() *inputBuffer {
+	if s.r == nil || s.rin != s.In {
+		s.r = newInputBuffer(s.In)
+		s.rin = s.In
+	}
+	return s.r
+}
+
+//  Read a single byte of input from s.In. When ctx can be canceled and no
+//  input is buffered the read is performed in a separate goroutine so that it
+//  can be abandoned. An abandoned read is not lost, its result is returned by
+//  the next call.
+func (s *Session) readByte(ctx context.Context) (byte, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if s.pending == nil {
+		r := s.reader()
+		if ctx.Done() == nil || r.Buffered() > 0 {
+			return r.ReadByte()
+		}
+		s.pending = make(chan readResult, 1)
+		go func(ch chan<- readResult) {
+			c, err := r.ReadByte()
+			ch <- readResult{c, err}
+		}(s.pending)
+	}
+	select {
+	case res := <-s.pending:
+		s.pending = nil
+		return res.c, res.err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+//  Read a line of input from s.In, without the trailing newline ("\n" or
+//  "\r\n"). A final line without a newline is returned without error; io.EOF
+//  is only returned when there is no more input.
+func (s *Session) readLine(ctx context.Context) ([]byte, error) {
+	return s.readLineFunc(ctx, func(p []byte, c byte) []byte { return append(p, c) })
+}
+
+//  Like readLine, but bytes are added to the line with appendByte (e.g.
+//  appendSecret). A partial line is wiped if an error is returned.
+func (s *Session) readLineFunc(ctx context.Context, appendByte func([]byte, byte) []byte) ([]byte, error) {
+	var line []byte
+	for {
+		c, err := s.readByte(ctx)
+		switch {
+		case err == io.EOF && len(line) > 0:
+			return line, nil
+		case err != nil:
+			wipeBytes(line)
+			return nil, err
+		case c == '\n':
+			if n := len(line); n > 0 && line[n-1] == '\r' {
+				line = line[:n-1]
+			}
+			return line, nil
+		}
+		line = appendByte(line, c)
+	}
+}
@@ -0,0 +1,172 @@
+package goline
+/*
+ *  Filename:    session_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:40:11 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "errors"
+    "io"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestSessionSay(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(""), out)
+    s.Say("Hello, World!")
+    s.SayTrimmed("Hello, World!   \t\n")
+    if expect := "Hello, World!\nHello, World!\n"; out.String() != expect {
+        T.Errorf("Unexpected output %#v != %#v", out.String(), expect)
+    }
+}
+
+func TestSessionAsk(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("abc\n12\n"), out)
+    var x int
+    s.Ask(&x, "Number? ", nil)
+    if x != 12 {
+        T.Errorf("Unexpected answer %d != %d", x, 12)
+    }
+    if expect := "Number? Error: "; !strings.HasPrefix(out.String(), expect) {
+        T.Errorf("Unexpected output %#v", out.String())
+    }
+}
+
+func TestSessionBuffering(T *testing.T) {
+    // Input buffered by one call must be available to the next.
+    s := NewSession(strings.NewReader("first\nsecond\n"), new(bytes.Buffer))
+    var a, b string
+    s.Ask(&a, "A? ", nil)
+    s.Ask(&b, "B? ", nil)
+    if a != "first" || b != "second" {
+        T.Errorf("Unexpected answers %#v, %#v", a, b)
+    }
+}
+
+func TestSessionAskSlice(T *testing.T) {
+    s := NewSession(strings.NewReader("1 2 3\n"), new(bytes.Buffer))
+    var xs []uint16
+    if err := s.Ask(&xs, "Numbers? ", nil); err != nil {
+        T.Fatalf("Unexpected error %v", err)
+    }
+    if len(xs) != 3 || xs[0] != 1 || xs[1] != 2 || xs[2] != 3 {
+        T.Errorf("Unexpected answer %#v", xs)
+    }
+}
+
+func TestSessionChoose(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("dog\n"), out)
+    i, v := s.Choose(func(m *Menu) {
+        m.Header = "Pets"
+        m.Question = "Which? "
+        m.Choice("cat", nil)
+        m.Choice("dog", nil)
+    })
+    if i != 1 || v.(Stringer).String() != "dog" {
+        T.Errorf("Unexpected choice %d %#v", i, v)
+    }
+    if expect := "Pets\n0. cat\n1. dog\nWhich? "; out.String() != expect {
+        T.Errorf("Unexpected output %#v != %#v", out.String(), expect)
+    }
+}
+
+func TestSessionAskContext(T *testing.T) {
+    pr, pw := io.Pipe()
+    defer pw.Close()
+    s := NewSession(pr, new(bytes.Buffer))
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+    defer cancel()
+    var x int
+    err := s.AskContext(ctx, &x, "Number? ", nil)
+    if _, ok := err.(ErrorCanceled); !ok {
+        T.Fatalf("Unexpected error %#v", err)
+    }
+    if !errors.Is(err, context.DeadlineExceeded) {
+        T.Errorf("Error does not wrap the context error: %v", err)
+    }
+
+    // Input typed after the prompt was abandoned goes to the next prompt.
+    go pw.Write([]byte("7\n"))
+    if err := s.Ask(&x, "Number? ", nil); err != nil {
+        T.Fatalf("Unexpected error %v", err)
+    }
+    if x != 7 {
+        T.Errorf("Unexpected answer %d != %d", x, 7)
+    }
+}
+
+func TestSessionAskContextDefault(T *testing.T) {
+    pr, pw := io.Pipe()
+    defer pw.Close()
+    s := NewSession(pr, new(bytes.Buffer))
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+    defer cancel()
+    var env string
+    err := s.AskContext(ctx, &env, "Environment? ", func(q *Question) {
+        q.Default = "staging"
+        q.DefaultOnTimeout = true
+    })
+    if err != nil {
+        T.Fatalf("Unexpected error %v", err)
+    }
+    if env != "staging" {
+        T.Errorf("Unexpected answer %#v", env)
+    }
+}
+
+func TestSessionConfirm(T *testing.T) {
+    s := NewSession(strings.NewReader("YES\nmaybe\noff\n\n"), new(bytes.Buffer))
+    if !s.Confirm("Continue? ", false, nil) {
+        T.Errorf("YES was not confirmed")
+    }
+    if s.Confirm("Continue? ", true, nil) {
+        T.Errorf("off was confirmed")
+    }
+    if !s.Confirm("Continue? ", true, func(q *Question) { q.Case = Upper }) {
+        T.Errorf("Default was not used")
+    }
+    ok, err := s.ConfirmErr("Continue? ", true, nil)
+    if ok || err != io.EOF {
+        T.Errorf("Unexpected result %v %v", ok, err)
+    }
+}
+
+func TestSessionConfirmStringSet(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("true\nyep\nnope\n\n"), out)
+    config := func(q *Question) {
+        q.In(StringSet{"yep", "nope"})
+        q.Default = "nope"
+    }
+    if !s.Confirm("Continue? ", false, config) {
+        T.Errorf("yep was not confirmed")
+    }
+    if !strings.Contains(out.String(), `("true")`) {
+        T.Errorf("Answer not in set accepted %#v", out.String())
+    }
+    ok, err := s.ConfirmErr("Continue? ", true, config)
+    if ok || err != nil {
+        T.Errorf("Unexpected result %v %v", ok, err)
+    }
+    if ok, err := s.ConfirmErr("Continue? ", true, config); ok || err != nil {
+        T.Errorf("Unexpected default %v %v", ok, err)
+    }
+}
+
+func TestSessionConfirmContext(T *testing.T) {
+    s := NewSession(strings.NewReader(""), new(bytes.Buffer))
+    ok, err := s.ConfirmContext(context.Background(), "Continue? ", true, nil)
+    if ok || err != io.EOF {
+        T.Errorf("Unexpected result %v %v", ok, err)
+    }
+}
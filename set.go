@@ -10,6 +10,7 @@ package goline
 import (
 	"fmt"
 	"strings"
+	"unicode"
 )
 
 // An interface for sets of values.
@@ -22,6 +23,21 @@ type CompletionSet interface {
 	Complete(x interface{}) (interface{}, error)
 }
 
+//  A CompletionSet that can list its members beginning with a prefix. The
+//  line editor completes answers in place when Tab is typed, and lists the
+//  candidates when Tab is typed twice. See LineEditor.Complete.
+type CandidateSet interface {
+	CompletionSet
+	Candidates(prefix string) []string
+}
+
+//  An AnswerSet that can list its members. Answers missing an
+//  EnumerableSet are met with suggestions of similar members. See Suggest.
+type EnumerableSet interface {
+	AnswerSet
+	Members() []string
+}
+
 type StringCompletionSet StringSet
 
 func (set StringCompletionSet) Has(x interface{}) bool {
@@ -30,16 +46,12 @@ func (set StringCompletionSet) Has(x interface{}) bool {
 func (set StringCompletionSet) String() string {
 	return StringSet(set).String()
 }
+func (set StringCompletionSet) Members() []string { return set }
 func (set StringCompletionSet) Complete(x interface{}) (interface{}, error) {
 	switch x.(type) {
 	case string:
 		y := x.(string)
-		var possible []string
-		for _, s := range set {
-			if strings.HasPrefix(s, y) {
-				possible = append(possible, s)
-			}
-		}
+		possible := set.Candidates(y)
 		switch len(possible) {
 		case 0:
 			return "", makeErrorNoCompletion(set, y)
@@ -52,6 +64,17 @@ func (set StringCompletionSet) Complete(x interface{}) (interface{}, error) {
 	panic(makeErrorMemberType(set, x))
 }
 
+//  The members of set beginning with prefix.
+func (set StringCompletionSet) Candidates(prefix string) []string {
+	var possible []string
+	for _, s := range set {
+		if strings.HasPrefix(s, prefix) {
+			possible = append(possible, s)
+		}
+	}
+	return possible
+}
+
 //  Composite answer sets (AnswerSetUnion and AnswerSetIntersection objects)
 //  are tools available to create more complex AnswerSet objects not provided
 //  by goline. For example open, or half-open, intervals can be constructed by
@@ -67,7 +90,7 @@ func compositeString(composite CompositeAnswerSet, name string) string {
 	for i := range strs {
 		strs[i] = composite.Set(i).String()
 	}
-	return fmt.Sprintf("%s of %s", strings.Join(strs, ", and "))
+	return fmt.Sprintf("%s of %s", name, strings.Join(strs, ", and "))
 }
 
 //  When making set intersections it is much easier to unknowingly create
@@ -212,6 +235,8 @@ func (set StringSet) Has(x interface{}) bool {
 	panic(makeErrorMemberType(set, x))
 }
 
+func (set StringSet) Members() []string { return set }
+
 //  A string using notation `{"item1", "item2", ...}`
 func (set StringSet) String() string {
 	n := len(set)
@@ -248,7 +273,8 @@ func (set shellCommandSet) Has(x interface{}) bool {
 	}
 	panic(makeErrorMemberType(set, x))
 }
-func (set shellCommandSet) String() string { return StringSet(set).String() }
+func (set shellCommandSet) String() string    { return StringSet(set).String() }
+func (set shellCommandSet) Members() []string { return set }
 func (set shellCommandSet) Complete(x interface{}) (interface{}, error) {
 	switch x.(type) {
 	case string:
@@ -263,6 +289,16 @@ func (set shellCommandSet) Complete(x interface{}) (interface{}, error) {
 	panic(makeErrorMemberType(set, x))
 }
 
+//  The commands beginning with prefix. Arguments are not completed, so there
+//  are no candidates once a command name has been typed in full.
+func (set shellCommandSet) Candidates(prefix string) []string {
+	name := strings.TrimLeftFunc(prefix, unicode.IsSpace)
+	if strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return nil
+	}
+	return StringCompletionSet(set).Candidates(name)
+}
+
 //  An interval with only one bound, X.
 type UintBounded struct {
 	Direction
@@ -309,7 +345,7 @@ func (r StringBounded) String() string {
 	if r.Direction == Above {
 		return fmt.Sprintf("range [%v, %s)", r.X, r.Infinity())
 	}
-	return fmt.Sprintf("range (%#s, %v]", r.Infinity(), r.X)
+	return fmt.Sprintf("range (%s, %#v]", r.Infinity(), r.X)
 }
 
 func (r UintBounded) Has(x interface{}) bool {
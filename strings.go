@@ -66,7 +66,6 @@ func makeStringer(s interface{}) Stringer {
     case Stringer:
         return s.(Stringer)
     default:
-        panic("Value must be type 'string' or 'Stringer'")
     }
-    return zeroStringer
+    panic("Value must be type 'string' or 'Stringer'")
 }
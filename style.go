@@ -0,0 +1,188 @@
+package goline
+
+/*
+ *  Filename:    style.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:55:06 UTC 2026
+ *  Description: Markup for ANSI colors and text styles.
+ */
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+//  Controls whether a Session renders style markup as ANSI escape sequences.
+//  With ColorAuto, markup is rendered only if the Session's Out is a terminal
+//  and the NO_COLOR environment variable is not set. Markup that is not
+//  rendered is removed. See Style.
+type ColorMode uint
+
+const (
+	ColorAuto ColorMode = iota
+	ColorAlways
+	ColorNever
+)
+
+//  Named styles usable in markup tags. The "error" style is used for error
+//  messages printed by Ask.
+var Styles = map[string]string{
+	"error":   "bold red",
+	"warning": "bold yellow",
+	"notice":  "cyan",
+}
+
+var styleAttrs = map[string]string{
+	"bold":      "1",
+	"dim":       "2",
+	"italic":    "3",
+	"underline": "4",
+	"blink":     "5",
+	"reverse":   "7",
+	"strike":    "9",
+}
+
+var styleColors = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+//  Wrap text in style markup for the given styles.
+//      goline.Say(goline.Style("done", "bold", "green"))
+//  Messages written by Say (including prompts, menu headers and error
+//  Responses) may contain style markup. A tag "{{style ...}}" begins styled
+//  text, and "{{/}}" returns to plain text.
+//      goline.Say("{{bold red}}Warning:{{/}} the disk is {{underline}}full{{/}}")
+//  A tag contains one or more of the following, separated by spaces.
+//      bold dim italic underline blink reverse strike
+//      black red green yellow blue magenta cyan white
+//      bright_black bright_red ... bright_white
+//      0 ... 255       a color from the 256 color palette
+//      #rrggbb         a truecolor (24-bit) color
+//      NAME            a style defined in Styles (e.g. "error")
+//  Colors are foreground colors unless prefixed with "on_" (e.g. "on_blue",
+//  "on_208", "on_#1e1e1e"). Tags that cannot be parsed are printed as they
+//  are, and "{{{{" is printed as "{{" (see EscapeMarkup).
+func Style(text string, styles ...string) string {
+	return "{{" + strings.Join(styles, " ") + "}}" + text + "{{/}}"
+}
+
+//  Escape text so that it is displayed literally by Say.
+func EscapeMarkup(text string) string { return strings.Replace(text, "{{", "{{{{", -1) }
+
+//  Returns true if markup written by s should be rendered as escape
+//  sequences.
+func (s *Session) colorEnabled() bool {
+	switch s.Color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	_, ok := s.outputTerminal()
+	return ok
+}
+
+//  Render the markup in msg. If color is false the markup is removed.
+func renderMarkup(msg string, color bool) string {
+	if !strings.Contains(msg, "{{") {
+		return msg
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(msg, "{{")
+		if i < 0 {
+			break
+		}
+		b.WriteString(msg[:i])
+		msg = msg[i:]
+		if strings.HasPrefix(msg, "{{{{") {
+			b.WriteString("{{")
+			msg = msg[4:]
+			continue
+		}
+		j := strings.Index(msg, "}}")
+		if j < 0 {
+			break
+		}
+		codes, ok := styleCodes(msg[2:j], 0)
+		if !ok {
+			// Not a tag, print the braces literally.
+			b.WriteString("{{")
+			msg = msg[2:]
+			continue
+		}
+		if color {
+			b.WriteString("\x1b[" + strings.Join(codes, ";") + "m")
+		}
+		msg = msg[j+2:]
+	}
+	b.WriteString(msg)
+	return b.String()
+}
+
+//  Returns the SGR parameters of the styles in tag. Named styles are
+//  expanded up to a fixed depth, so that cyclic definitions fail.
+func styleCodes(tag string, depth int) ([]string, bool) {
+	if tag == "/" {
+		return []string{"0"}, true
+	}
+	words := strings.Fields(tag)
+	if len(words) == 0 || depth > 4 {
+		return nil, false
+	}
+	var codes []string
+	for _, w := range words {
+		if code, ok := styleAttrs[w]; ok {
+			codes = append(codes, code)
+			continue
+		}
+		if def, ok := Styles[w]; ok {
+			more, ok := styleCodes(def, depth+1)
+			if !ok {
+				return nil, false
+			}
+			codes = append(codes, more...)
+			continue
+		}
+		code, ok := colorCode(w)
+		if !ok {
+			return nil, false
+		}
+		codes = append(codes, code)
+	}
+	return codes, true
+}
+
+//  Returns the SGR parameters for a foreground or ("on_" prefixed)
+//  background color.
+func colorCode(w string) (string, bool) {
+	base, ext := 30, "38"
+	if strings.HasPrefix(w, "on_") {
+		w, base, ext = w[3:], 40, "48"
+	}
+	if strings.HasPrefix(w, "bright_") {
+		w, base = w[7:], base+60
+	}
+	for i, name := range styleColors {
+		if w == name {
+			return strconv.Itoa(base + i), true
+		}
+	}
+	if base >= 90 {
+		return "", false
+	}
+	if strings.HasPrefix(w, "#") && len(w) == 7 {
+		rgb, err := strconv.ParseUint(w[1:], 16, 32)
+		if err != nil {
+			return "", false
+		}
+		return ext + ";2;" + strconv.Itoa(int(rgb>>16)) + ";" +
+			strconv.Itoa(int(rgb>>8&0xff)) + ";" + strconv.Itoa(int(rgb&0xff)), true
+	}
+	if n, err := strconv.ParseUint(w, 10, 8); err == nil {
+		return ext + ";5;" + strconv.Itoa(int(n)), true
+	}
+	return "", false
+}
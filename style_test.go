@@ -0,0 +1,68 @@
+package goline
+/*
+ *  Filename:    style_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:55:06 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+func TestRenderMarkup(T *testing.T) {
+    for _, test := range []struct{ msg, color, plain string }{
+        {"plain", "plain", "plain"},
+        {"{{bold red}}x{{/}}", "\x1b[1;31mx\x1b[0m", "x"},
+        {"{{on_bright_blue underline}}x", "\x1b[104;4mx", "x"},
+        {"{{208 on_#1e2f3a}}x", "\x1b[38;5;208;48;2;30;47;58mx", "x"},
+        {"{{error}}x", "\x1b[1;31mx", "x"},
+        {"{{nope}} {{}} {{red", "{{nope}} {{}} {{red", "{{nope}} {{}} {{red"},
+        {"{{bright_208}}{{256}}", "{{bright_208}}{{256}}", "{{bright_208}}{{256}}"},
+        {EscapeMarkup("{{red}}"), "{{red}}", "{{red}}"},
+        {Style("ok", "green"), "\x1b[32mok\x1b[0m", "ok"},
+    } {
+        if out := renderMarkup(test.msg, true); out != test.color {
+            T.Errorf("Unexpected rendering of %#v: %#v", test.msg, out)
+        }
+        if out := renderMarkup(test.msg, false); out != test.plain {
+            T.Errorf("Unexpected plain rendering of %#v: %#v", test.msg, out)
+        }
+    }
+}
+
+func TestSessionColor(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("x\n1\n"), out)
+    s.Color = ColorAlways
+    s.Say("{{bold}}Name?{{/}} ")
+    if out.String() != "\x1b[1mName?\x1b[0m " {
+        T.Errorf("Unexpected output %#v", out.String())
+    }
+    out.Reset()
+    var x int
+    s.Ask(&x, "? ", nil)
+    if !strings.Contains(out.String(), "\x1b[1;31mError: ") {
+        T.Errorf("Error not styled %#v", out.String())
+    }
+
+    // Markup in the answer echoed by an error is not rendered.
+    out.Reset()
+    var word string
+    s = NewSession(strings.NewReader("{{red}}x\nyes\n"), out)
+    s.Color = ColorAlways
+    s.Ask(&word, "? ", func(q *Question) { q.In(StringSet{"yes", "no"}) })
+    if !strings.Contains(out.String(), `"{{red}}x"`) || strings.Contains(out.String(), "\x1b[31m") {
+        T.Errorf("Markup in error rendered %#v", out.String())
+    }
+
+    T.Setenv("NO_COLOR", "1")
+    out.Reset()
+    s.Color = ColorAuto
+    s.Say("{{bold}}Name?{{/}}")
+    if out.String() != "Name?\n" {
+        T.Errorf("Unexpected output %#v", out.String())
+    }
+}
@@ -0,0 +1,133 @@
+package goline
+
+/*
+ *  Filename:    submenu.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:02:34 UTC 2026
+ *  Description: Nested menus navigated with ChoosePath.
+ */
+import (
+	"context"
+	"strings"
+)
+
+//  The names of the choices ChoosePath adds to submenus for returning to
+//  the parent menu and to the top menu.
+var (
+	MenuBack = "back"
+	MenuTop  = "top"
+)
+
+//  Separates the headers in the breadcrumb displayed above submenus.
+var BreadcrumbSep = " > "
+
+//  A choice opening a submenu.
+type submenuChoice struct {
+	Stringer
+	config func(*Menu)
+}
+
+//  Append a choice that opens a submenu configured by config when it is
+//  chosen with ChoosePath. Submenus can be nested.
+//      goline.ChoosePath(func(m *goline.Menu) {
+//          m.Header = "Main"
+//          m.Submenu("Settings", func(m *goline.Menu) {
+//              m.Choice("Network", nil)
+//              m.Choice("Display", nil)
+//          })
+//          m.Choice("Quit", nil)
+//      })
+func (m *Menu) Submenu(name interface{}, config func(*Menu)) {
+	m.Choice(submenuChoice{makeStringer(name), config}, nil)
+}
+
+//  Prompt the user to choose from a menu whose choices may open submenus
+//  (see Menu.Submenu). Submenus get MenuBack and MenuTop choices (unless
+//  they have choices with those names), and their header is a breadcrumb of
+//  the headers (or, for menus without a header, the names of the choices)
+//  leading to them. Returns the index chosen in
+//  each menu on the path to the final choice, and the choices themselves.
+//  Actions are called as with Choose.
+func ChoosePath(config func(*Menu)) (path []int, vs []interface{}) {
+	return DefaultSession.ChoosePath(config)
+}
+
+//  Like ChoosePath, but the prompt is abandoned when ctx is done. See
+//  ChooseContext.
+func ChoosePathContext(ctx context.Context, config func(*Menu)) (path []int, vs []interface{}, err error) {
+	return DefaultSession.ChoosePathContext(ctx, config)
+}
+
+//  Like ChoosePath, but the menus are written to s.Out and input is read
+//  from s.In.
+func (s *Session) ChoosePath(config func(*Menu)) (path []int, vs []interface{}) {
+	m, path, vs, err := s.choosePath(context.Background(), config)
+	if err != nil && m.Panic == nil {
+		panic(err)
+	}
+	return
+}
+
+//  Like ChoosePathContext, but the menus are written to s.Out and input is
+//  read from s.In.
+func (s *Session) ChoosePathContext(ctx context.Context, config func(*Menu)) (path []int, vs []interface{}, err error) {
+	_, path, vs, err = s.choosePath(ctx, config)
+	return
+}
+
+//  A menu on the ChoosePath navigation stack.
+type menuFrame struct {
+	config func(*Menu)
+	crumb  string
+}
+
+func (s *Session) choosePath(ctx context.Context, config func(*Menu)) (m *Menu, path []int, vs []interface{}, err error) {
+	stack := []menuFrame{{config: config}}
+	for {
+		depth := len(stack) - 1
+		var i int
+		var v interface{}
+		back, top := -1, -1
+		m, i, v, err = s.choose(ctx, func(m *Menu) {
+			stack[depth].config(m)
+			if m.Header != "" {
+				stack[depth].crumb = m.Header
+			}
+			if depth == 0 {
+				return
+			}
+			var crumbs []string
+			for j := range stack {
+				if stack[j].crumb != "" {
+					crumbs = append(crumbs, stack[j].crumb)
+				}
+			}
+			m.Header = strings.Join(crumbs, BreadcrumbSep)
+			// Navigation choices named like the menu's own are left out.
+			if m.index(MenuBack) < 0 {
+				back = m.Len()
+				m.Choice(MenuBack, nil)
+			}
+			if depth > 1 && m.index(MenuTop) < 0 {
+				top = m.Len()
+				m.Choice(MenuTop, nil)
+			}
+		})
+		if err != nil {
+			return m, nil, nil, err
+		}
+		// Choices added for navigation follow the menu's own choices.
+		switch sub, ok := v.(submenuChoice); {
+		case i == back:
+			stack, path, vs = stack[:depth], path[:depth-1], vs[:depth-1]
+		case i == top:
+			stack, path, vs = stack[:1], nil, nil
+		case ok:
+			stack = append(stack, menuFrame{config: sub.config, crumb: sub.String()})
+			path, vs = append(path, i), append(vs, sub.Stringer)
+		default:
+			return m, append(path, i), append(vs, v), nil
+		}
+	}
+}
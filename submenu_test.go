@@ -0,0 +1,73 @@
+package goline
+/*
+ *  Filename:    submenu_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:02:34 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "fmt"
+    "strings"
+    "testing"
+)
+
+func settingsMenu(m *Menu) {
+    m.Header = "Main"
+    m.Submenu("Settings", func(m *Menu) {
+        m.Submenu("Network", func(m *Menu) {
+            m.Header = "Network settings"
+            m.Choice("ethernet", nil)
+            m.Choice("wifi", nil)
+        })
+        m.Choice("Display", nil)
+    })
+    m.Choice("Quit", nil)
+}
+
+func TestChoosePath(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("Settings\n0\ntop\n0\n0\nback\n0\nwifi\n"), out)
+    path, vs, err := s.ChoosePathContext(context.Background(), settingsMenu)
+    if err != nil {
+        T.Fatalf("Unexpected error %v", err)
+    }
+    if got := fmt.Sprint(path, vs); got != "[0 0 1] [Settings Network wifi]" {
+        T.Errorf("Unexpected path %s", got)
+    }
+    for _, expect := range []string{
+        "Main > Settings\n0. Network\n1. Display\n2. back\n",
+        "Main > Settings > Network settings\n0. ethernet\n1. wifi\n2. back\n3. top\n",
+    } {
+        if !strings.Contains(out.String(), expect) {
+            T.Errorf("Menu %#v not displayed in %#v", expect, out.String())
+        }
+    }
+
+    s = NewSession(strings.NewReader("1\n"), new(bytes.Buffer))
+    if path, vs := s.ChoosePath(settingsMenu); fmt.Sprint(path, vs) != "[1] [Quit]" {
+        T.Errorf("Unexpected path %v %v", path, vs)
+    }
+}
+
+func TestChoosePathNamedBack(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("0\nback\n"), out)
+    path, vs, err := s.ChoosePathContext(context.Background(), func(m *Menu) {
+        m.Submenu("Deploy", func(m *Menu) {
+            m.Choice("forward", nil)
+            m.Choice("back", nil)
+        })
+    })
+    if err != nil {
+        T.Fatalf("Unexpected error %v", err)
+    }
+    if got := fmt.Sprint(path, vs); got != "[0 1] [Deploy back]" {
+        T.Errorf("Unexpected path %s", got)
+    }
+    if expect := "0. forward\n1. back\n\n"; !strings.Contains(out.String(), expect) {
+        T.Errorf("Menu %#v not displayed in %#v", expect, out.String())
+    }
+}
@@ -0,0 +1,146 @@
+package goline
+
+/*
+ *  Filename:    suggest.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:09:41 UTC 2026
+ *  Description: "Did you mean" suggestions for answers not in a set.
+ */
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//  The maximum number of suggestions made for an answer not in a set.
+var MaxSuggestions = 3
+
+//  The number of single rune insertions, deletions, substitutions and
+//  transpositions of adjacent runes needed to turn a into b, ignoring case.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+	// Three rows of the distance matrix are kept to detect transpositions.
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d := minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d = minInt(d, prev2[j-2]+1)
+			}
+			cur[j] = d
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+func minInt(x int, xs ...int) int {
+	for _, y := range xs {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
+
+//  The members closest to x by EditDistance, nearest first. Only members
+//  within a third of the length of x (at least one edit) are suggested, and
+//  no more than MaxSuggestions. Members differing from x only in case are
+//  suggested first; members equal to x are not suggested.
+//      goline.Suggest("delpoy", []string{"deploy", "destroy", "status"})
+//      // []string{"deploy"}
+func Suggest(x string, members []string) []string {
+	limit := (utf8.RuneCountInString(x) + 2) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	type suggestion struct {
+		member string
+		dist   int
+	}
+	var near []suggestion
+	for _, m := range members {
+		if d := EditDistance(x, m); m != x && d <= limit {
+			near = append(near, suggestion{m, d})
+		}
+	}
+	sort.SliceStable(near, func(i, j int) bool { return near[i].dist < near[j].dist })
+	var sugg []string
+	for i := 0; i < len(near) && i < MaxSuggestions; i++ {
+		sugg = append(sugg, near[i].member)
+	}
+	return sugg
+}
+
+//  Suggest replacements for x, an answer missing set. The command names of
+//  shell menus are corrected, keeping their arguments.
+func suggestions(set interface{}, x interface{}) []string {
+	y, ok := x.(string)
+	if !ok || strings.TrimFunc(y, unicode.IsSpace) == "" {
+		return nil
+	}
+	switch set.(type) {
+	case shellCommandSet:
+		name, args := splitShellCmd(y)
+		sugg := Suggest(name, set.(shellCommandSet).Members())
+		if args != "" {
+			for i := range sugg {
+				sugg[i] += " " + args
+			}
+		}
+		return sugg
+	case EnumerableSet:
+		return Suggest(y, set.(EnumerableSet).Members())
+	}
+	return nil
+}
+
+//  A sentence offering suggestions, like "Did you mean 'a' or 'b'?".
+func didYouMean(msg string, sugg []string) string {
+	quoted := make([]string, len(sugg))
+	for i := range sugg {
+		quoted[i] = "'" + sugg[i] + "'"
+	}
+	last := len(quoted) - 1
+	if last == 0 {
+		return msg + " " + quoted[0] + "?"
+	}
+	return msg + " " + strings.Join(quoted[:last], ", ") + " or " + quoted[last] + "?"
+}
+
+//  The suggestions attached to err, an error returned by Question.parse.
+func errorSuggestions(err error) []string {
+	switch err.(type) {
+	case ErrorNotInSet:
+		return err.(ErrorNotInSet).Suggestions
+	case ErrorNoCompletion:
+		return err.(ErrorNoCompletion).Suggestions
+	}
+	return nil
+}
+
+//  Ask the user whether the best suggestion for an unrecognized answer to q
+//  was meant (see Question.ConfirmSuggestion). The accepted suggestion is
+//  returned.
+func (s *Session) confirmSuggestion(ctx context.Context, q *Question, err error) (string, bool, error) {
+	sugg := errorSuggestions(err)
+	if !q.ConfirmSuggestion || q.Hidden || q.typ.IsSliceType() || len(sugg) == 0 {
+		return "", false, nil
+	}
+	msg := didYouMean(q.Responses[DidYouMean], sugg[:1]) + "  "
+	yes, err := s.ConfirmContext(ctx, msg, false, func(q *Question) { q.NoHistory = true })
+	return sugg[0], yes, err
+}
@@ -0,0 +1,101 @@
+package goline
+/*
+ *  Filename:    suggest_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 01:09:41 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+func TestEditDistance(T *testing.T) {
+    for _, test := range []struct {
+        a, b   string
+        expect int
+    }{
+        {"", "", 0},
+        {"deploy", "deploy", 0},
+        {"delpoy", "deploy", 1},
+        {"dploy", "deploy", 1},
+        {"Deploy", "deploy", 0},
+        {"kitten", "sitting", 3},
+        {"", "abc", 3},
+    } {
+        if d := EditDistance(test.a, test.b); d != test.expect {
+            T.Errorf("%q %q: Unexpected distance %d != %d", test.a, test.b, d, test.expect)
+        }
+    }
+}
+
+func TestSuggest(T *testing.T) {
+    cmds := []string{"deploy", "destroy", "describe", "status", "delete"}
+    for _, test := range []struct {
+        x      string
+        expect []string
+    }{
+        {"delpoy", []string{"deploy"}},
+        {"destory", []string{"destroy", "deploy"}},
+        {"deploy", nil},
+        {"xyzzy", nil},
+        {"de", nil},
+        {"deletr", []string{"delete"}},
+        {"Deploy", []string{"deploy"}},
+        {"DELETEE", []string{"delete"}},
+        {"Delpoy", []string{"deploy"}},
+    } {
+        if sugg := Suggest(test.x, cmds); !reflect.DeepEqual(sugg, test.expect) {
+            T.Errorf("%q: Unexpected suggestions %#v", test.x, sugg)
+        }
+    }
+}
+
+func TestAskSuggestion(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("delpoy\ndeploy\n"), out)
+    var resp string
+    err := s.Ask(&resp, "? ", func(q *Question) {
+        q.In(StringSet{"deploy", "destroy", "status"})
+    })
+    if err != nil || resp != "deploy" {
+        T.Fatalf("Unexpected answer %q %v", resp, err)
+    }
+    if msg := `Unrecognized answer "delpoy". Did you mean 'deploy'?`; !strings.Contains(out.String(), msg) {
+        T.Errorf("Suggestion not printed %#v", out.String())
+    }
+
+    out.Reset()
+    s = NewSession(strings.NewReader("stauts\ny\n"), out)
+    err = s.Ask(&resp, "? ", func(q *Question) {
+        q.In(StringSet{"deploy", "destroy", "status"})
+        q.ConfirmSuggestion = true
+    })
+    if err != nil || resp != "status" {
+        T.Fatalf("Unexpected answer %q %v", resp, err)
+    }
+    if expect := "? Did you mean 'status'?  |no|  "; out.String() != expect {
+        T.Errorf("Unexpected output %#v != %#v", out.String(), expect)
+    }
+}
+
+func TestChooseShellSuggestion(T *testing.T) {
+    var args string
+    config := func(m *Menu) {
+        m.Shell = true
+        m.ConfirmSuggestion = true
+        m.Choice("deploy", func(name, arg string) { args = arg })
+        m.Choice("destroy", nil)
+    }
+    s := NewSession(strings.NewReader("delpoy prod\nn\ndeploy staging\n"), new(bytes.Buffer))
+    if i, _ := s.Choose(config); i != 0 || args != "staging" {
+        T.Errorf("Unexpected choice %d %q", i, args)
+    }
+    s = NewSession(strings.NewReader("delpoy prod\ny\n"), new(bytes.Buffer))
+    if i, _ := s.Choose(config); i != 0 || args != "prod" {
+        T.Errorf("Unexpected choice %d %q", i, args)
+    }
+}
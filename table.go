@@ -0,0 +1,263 @@
+package goline
+
+/*
+ *  Filename:    table.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:58:21 UTC 2026
+ *  Description: Tables of rows with headers and aligned columns.
+ */
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+//  The alignment of the cells in a Table column.
+type Alignment uint
+
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+	AlignCenter
+)
+
+//  A Table configures how Tabulate prints rows of data.
+type Table struct {
+	// Column headers. When rows are structs, the default headers are the
+	// field names (or the `goline:"..."` tags) of the exported fields.
+	Headers []string
+	// The alignment of each column. Columns without an Alignment are left
+	// aligned.
+	Align []Alignment
+	// Draw borders around the table and between its columns.
+	Border bool
+	// The maximum width of the table. Cells of the widest columns are
+	// truncated with Ellipsis so that the table fits. Defaults to the
+	// terminal width (see Session.Width).
+	Width int
+	// Marks the truncation of cells. Defaults to "…".
+	Ellipsis string
+}
+
+//  Print rows as a table with aligned columns. Rows may be a [][]string, a
+//  slice of slices of any other type (cells are formatted like List items,
+//  or with fmt.Sprint), or a slice of structs (or pointers to structs) whose
+//  exported fields are the cells. Fields tagged `goline:"-"` are omitted.
+//      type Host struct {
+//          Name string
+//          Load float64 `goline:"Load avg"`
+//      }
+//      goline.Tabulate([]Host{{"web1", 0.25}, {"db1", 2}}, func(t *goline.Table) {
+//          t.Align = []goline.Alignment{goline.AlignLeft, goline.AlignRight}
+//      })
+//      /* Outputs:
+//       *  Name  Load avg
+//       *  ----  --------
+//       *  web1      0.25
+//       *  db1          2
+//       */
+//  Like List, Tabulate panics if rows is not a slice of a usable type.
+func Tabulate(rows interface{}, config func(*Table)) {
+	DefaultSession.Tabulate(rows, config)
+}
+
+//  Like Tabulate, but the table is written to s.Out. Long tables are paged
+//  (see Session.PageAt).
+func (s *Session) Tabulate(rows interface{}, config func(*Table)) {
+	t := &Table{Ellipsis: "…"}
+	cells, headers := tableCells(rows)
+	t.Headers = headers
+	if config != nil {
+		config(t)
+	}
+	if t.Width <= 0 {
+		t.Width = s.textWidth()
+	}
+	s.page(func() { t.write(s.writer(), cells) })
+}
+
+//  Convert rows to strings, and find default headers for rows of structs.
+func tableCells(rows interface{}) (cells [][]string, headers []string) {
+	rval := reflect.ValueOf(rows)
+	if rval.Kind() != reflect.Slice {
+		panic(errors.New("Tabulate given non-Slice types."))
+	}
+	elem := rval.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	var fields []int
+	switch elem.Kind() {
+	case reflect.Struct:
+		for i := 0; i < elem.NumField(); i++ {
+			f := elem.Field(i)
+			name := f.Tag.Get("goline")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			fields = append(fields, i)
+			headers = append(headers, name)
+		}
+	case reflect.Slice, reflect.Array:
+	default:
+		panic(errors.New("Tabulate rows must be slices or structs"))
+	}
+	isStruct := elem.Kind() == reflect.Struct
+	cells = make([][]string, rval.Len())
+	for i := range cells {
+		row := reflect.Indirect(rval.Index(i))
+		switch {
+		case !row.IsValid():
+			// A nil pointer is an empty row.
+			cells[i] = make([]string, len(fields))
+		case isStruct:
+			for _, j := range fields {
+				cells[i] = append(cells[i], cellString(row.Field(j)))
+			}
+		default:
+			cells[i] = make([]string, row.Len())
+			for j := range cells[i] {
+				cells[i][j] = cellString(row.Index(j))
+			}
+		}
+	}
+	return cells, headers
+}
+
+func cellString(v reflect.Value) string {
+	switch x := v.Interface().(type) {
+	case string:
+		return x
+	case Stringer:
+		return x.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func (t *Table) align(col int) Alignment {
+	if col < len(t.Align) {
+		return t.Align[col]
+	}
+	return AlignLeft
+}
+
+//  Returns the widths of the columns, shrinking the widest until the table
+//  fits in t.Width.
+func (t *Table) columnWidths(cells [][]string) []int {
+	var widths []int
+	measure := func(row []string) {
+		for j, cell := range row {
+			if j == len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := StringWidth(cell); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+	measure(t.Headers)
+	for _, row := range cells {
+		measure(row)
+	}
+	// Columns are separated by 2 spaces, or by " | " within borders.
+	avail := t.Width - 2*(len(widths)-1)
+	if t.Border {
+		avail = t.Width - 3*(len(widths)-1) - 4
+	}
+	for {
+		total, widest := 0, 0
+		for j, w := range widths {
+			total += w
+			if w > widths[widest] {
+				widest = j
+			}
+		}
+		if total <= avail || widths[widest] <= 1 {
+			return widths
+		}
+		widths[widest]--
+	}
+}
+
+//  Truncate str to the display width w, ending it with ellipsis.
+func truncate(str string, w int, ellipsis string) string {
+	if StringWidth(str) <= w {
+		return str
+	}
+	w -= StringWidth(ellipsis)
+	var b strings.Builder
+	for _, r := range str {
+		if w -= RuneWidth(r); w < 0 {
+			break
+		}
+		b.WriteRune(r)
+	}
+	return b.String() + ellipsis
+}
+
+//  Pad str to the display width w with the given alignment.
+func alignCell(str string, w int, a Alignment) string {
+	n := w - StringWidth(str)
+	if n <= 0 {
+		return str
+	}
+	switch a {
+	case AlignRight:
+		return strings.Repeat(" ", n) + str
+	case AlignCenter:
+		return strings.Repeat(" ", n/2) + str + strings.Repeat(" ", n-n/2)
+	}
+	return str + strings.Repeat(" ", n)
+}
+
+func (t *Table) write(wr io.Writer, cells [][]string) {
+	widths := t.columnWidths(cells)
+	if len(widths) == 0 {
+		return
+	}
+	line := func(row []string) {
+		padded := make([]string, len(widths))
+		for j, w := range widths {
+			var cell string
+			if j < len(row) {
+				cell = truncate(row[j], w, t.Ellipsis)
+			}
+			padded[j] = alignCell(cell, w, t.align(j))
+		}
+		if t.Border {
+			fSay(wr, "| "+strings.Join(padded, " | ")+" |", false)
+		} else {
+			fSay(wr, strings.Join(padded, "  "), true)
+		}
+	}
+	rule := func() {
+		dashes := make([]string, len(widths))
+		for j, w := range widths {
+			dashes[j] = strings.Repeat("-", w)
+		}
+		if t.Border {
+			fSay(wr, "+-"+strings.Join(dashes, "-+-")+"-+", false)
+		} else {
+			fSay(wr, strings.Join(dashes, "  "), true)
+		}
+	}
+	if t.Border {
+		rule()
+	}
+	if len(t.Headers) > 0 {
+		line(t.Headers)
+		rule()
+	}
+	for _, row := range cells {
+		line(row)
+	}
+	if t.Border && len(cells) > 0 {
+		rule()
+	}
+}
@@ -0,0 +1,77 @@
+package goline
+/*
+ *  Filename:    table_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:58:21 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+func testTable(T *testing.T, name string, rows interface{}, config func(*Table), expect ...string) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(""), out)
+    s.Tabulate(rows, func(t *Table) {
+        t.Width = 80
+        if config != nil {
+            config(t)
+        }
+    })
+    if e := strings.Join(expect, "\n") + "\n"; out.String() != e {
+        T.Errorf("%s: Unexpected output\n%s\n!=\n%s", name, out.String(), e)
+    }
+}
+
+type testHost struct {
+    Name   string
+    Load   float64 `goline:"Load avg"`
+    secret string
+    Owner  string `goline:"-"`
+}
+
+func TestTabulate(T *testing.T) {
+    hosts := []testHost{{"web1", 0.25, "", ""}, {"db1", 2, "", ""}}
+    testTable(T, "Structs", hosts, func(t *Table) {
+        t.Align = []Alignment{AlignLeft, AlignRight}
+    },
+        "Name  Load avg",
+        "----  --------",
+        "web1      0.25",
+        "db1          2")
+    testTable(T, "Struct pointers", []*testHost{&hosts[1], nil}, nil,
+        "Name  Load avg",
+        "----  --------",
+        "db1   2",
+        "")
+    testTable(T, "Borders", [][]string{{"a", "bcd"}, {"efg"}}, func(t *Table) {
+        t.Headers = []string{"X", "Y"}
+        t.Align = []Alignment{AlignCenter}
+        t.Border = true
+    },
+        "+-----+-----+",
+        "|  X  | Y   |",
+        "+-----+-----+",
+        "|  a  | bcd |",
+        "| efg |     |",
+        "+-----+-----+")
+    testTable(T, "Truncation", [][]interface{}{{"short", "a much longer cell", 42}}, func(t *Table) {
+        t.Width = 20
+    },
+        "short  a much l…  42")
+    testTable(T, "Wide runes", [][]string{{"日本語", "x"}, {"go", "y"}}, nil,
+        "日本語  x",
+        "go      y")
+    testTable(T, "Slice pointers", []*[]string{nil, {"a", "b"}}, nil,
+        "",
+        "a  b")
+
+    out := new(bytes.Buffer)
+    NewSession(strings.NewReader(""), out).Tabulate([]struct{ a int }{{1}}, nil)
+    if out.String() != "" {
+        T.Errorf("Unexpected output for unexported fields %#v", out.String())
+    }
+}
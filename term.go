@@ -0,0 +1,212 @@
+package goline
+
+/*
+ *  Filename:    term.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:47:30 UTC 2026
+ *  Description: Reading input from terminals in raw mode.
+ */
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//  Control characters read from terminals in raw mode.
+const (
+	keyCtrlC     = 0x03
+	keyCtrlD     = 0x04
+	keyCtrlH     = 0x08
+	keyCtrlU     = 0x15
+	keyBackspace = 0x7F
+)
+
+//  Returns the file descriptor of s.In and true if s.In is a terminal.
+func (s *Session) inputTerminal() (uintptr, bool) {
+	f, ok := s.In.(interface {
+		Fd() uintptr
+	})
+	if !ok {
+		return 0, false
+	}
+	fd := f.Fd()
+	return fd, isTerminal(fd)
+}
+
+//  Returns the file descriptor of s.Out and true if s.Out is a terminal.
+func (s *Session) outputTerminal() (uintptr, bool) {
+	f, ok := s.Out.(interface {
+		Fd() uintptr
+	})
+	if !ok {
+		return 0, false
+	}
+	fd := f.Fd()
+	return fd, isTerminal(fd)
+}
+
+//  Returns the width of the terminal s.Out writes to. If s.Out is not a
+//  terminal, or its size cannot be determined, the COLUMNS environment
+//  variable is used. The default width is 80 columns.
+func (s *Session) Width() int {
+	if fd, ok := s.outputTerminal(); ok {
+		if w, _, ok := termSize(fd); ok {
+			return w
+		}
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
+
+//  Returns the height of the terminal s.Out writes to. Like Width, but the
+//  LINES environment variable is used if the height cannot be determined.
+//  The default height is 24 lines.
+func (s *Session) Height() int {
+	if fd, ok := s.outputTerminal(); ok {
+		if _, h, ok := termSize(fd); ok && h > 0 {
+			return h
+		}
+	}
+	if h, err := strconv.Atoi(os.Getenv("LINES")); err == nil && h > 0 {
+		return h
+	}
+	return 24
+}
+
+//  Read a key from a terminal in raw mode. Escape sequences (e.g. arrow keys)
+//  are returned whole, other keys as a single UTF-8 encoded rune.
+func (s *Session) readKey(ctx context.Context) (string, error) {
+	var seq []byte
+	for {
+		c, err := s.readByte(ctx)
+		if err != nil {
+			return "", err
+		}
+		seq = append(seq, c)
+		switch {
+		case seq[0] == 0x1b:
+			// Control sequences end with a byte in the range 0x40-0x7E.
+			if len(seq) == 2 && seq[1] != '[' && seq[1] != 'O' ||
+				len(seq) > 2 && c >= 0x40 && c <= 0x7e {
+				return string(seq), nil
+			}
+		case utf8.FullRune(seq):
+			return string(seq), nil
+		}
+	}
+}
+
+//  Read the user's answer to q after the prompt has been displayed. If q.Twice
+//  is set the answer is read a second time and the two must match.
+func (s *Session) readAnswer(ctx context.Context, q *Question, prompt string) ([]byte, error) {
+	resp, err := s.readInput(ctx, q, prompt)
+	if err != nil || !q.Twice {
+		return resp, err
+	}
+	s.Say(q.Responses[Reenter])
+	again, err := s.readInput(ctx, q, q.Responses[Reenter])
+	defer wipeBytes(again)
+	switch {
+	case err != nil:
+		wipeBytes(resp)
+		return nil, err
+	case !bytes.Equal(resp, again):
+		wipeBytes(resp)
+		return nil, NewErrorRecoverable(q.Responses[Mismatch])
+	}
+	return resp, nil
+}
+
+//  Read a single line of input for q. The line editor is used if q.Edit is
+//  set and s.In is a terminal.
+func (s *Session) readInput(ctx context.Context, q *Question, prompt string) ([]byte, error) {
+	if q.Hidden {
+		return s.readSecret(ctx, q.Mask)
+	}
+	if fd, ok := s.inputTerminal(); ok && q.Edit {
+		return s.readEdited(ctx, fd, q, prompt)
+	}
+	return s.readLine(ctx)
+}
+
+//  Read a line of input without echoing it. If mask is not zero it is echoed
+//  once for each rune typed. Input that is not from a terminal is read a
+//  line at a time (it is never echoed by goline). Either way, buffers
+//  outgrown by the secret are wiped.
+func (s *Session) readSecret(ctx context.Context, mask rune) ([]byte, error) {
+	fd, ok := s.inputTerminal()
+	if !ok {
+		return s.readLineFunc(ctx, appendSecret)
+	}
+	restore, err := makeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	echo := func(s *Session, str string, n int) {
+		if mask != 0 {
+			io.WriteString(s.Out, strings.Repeat(str, n))
+		}
+	}
+	line := make([]byte, 0, 64)
+	for {
+		c, err := s.readByte(ctx)
+		if err != nil {
+			wipeBytes(line)
+			return nil, err
+		}
+		switch c {
+		case '\r', '\n':
+			s.Say("")
+			return line, nil
+		case keyCtrlC:
+			wipeBytes(line)
+			s.Say("")
+			return nil, ErrorInterrupt
+		case keyCtrlD:
+			if len(line) == 0 {
+				s.Say("")
+				return nil, io.EOF
+			}
+		case keyBackspace, keyCtrlH:
+			if len(line) > 0 {
+				_, n := utf8.DecodeLastRune(line)
+				wipeBytes(line[len(line)-n:])
+				line = line[:len(line)-n]
+				echo(s, "\b \b", 1)
+			}
+		case keyCtrlU:
+			echo(s, "\b \b", utf8.RuneCount(line))
+			wipeBytes(line)
+			line = line[:0]
+		default:
+			if c < ' ' {
+				// Ignore other control characters.
+				continue
+			}
+			line = appendSecret(line, c)
+			if utf8.RuneStart(c) {
+				echo(s, string(mask), 1)
+			}
+		}
+	}
+}
+
+//  Append c to the secret p. When p must grow the old buffer is wiped.
+func appendSecret(p []byte, c byte) []byte {
+	if len(p) == cap(p) {
+		q := make([]byte, len(p), 2*cap(p)+1)
+		copy(q, p)
+		wipeBytes(p)
+		p = q
+	}
+	return append(p, c)
+}
@@ -0,0 +1,72 @@
+//go:build linux
+
+package goline
+
+/*
+ *  Filename:    term_linux.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:47:30 UTC 2026
+ *  Description: Terminal control through termios ioctls.
+ */
+import (
+	"syscall"
+	"unsafe"
+)
+
+func ioctl(fd, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func getTermios(fd uintptr) (*syscall.Termios, error) {
+	t := new(syscall.Termios)
+	if err := ioctl(fd, syscall.TCGETS, unsafe.Pointer(t)); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func setTermios(fd uintptr, t *syscall.Termios) error {
+	return ioctl(fd, syscall.TCSETS, unsafe.Pointer(t))
+}
+
+//  Returns true if fd refers to a terminal.
+func isTerminal(fd uintptr) bool {
+	_, err := getTermios(fd)
+	return err == nil
+}
+
+//  Returns the number of columns and rows of the terminal fd.
+func termSize(fd uintptr) (cols, rows int, ok bool) {
+	var ws struct{ Row, Col, Xpixel, Ypixel uint16 }
+	if err := ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil || ws.Col == 0 {
+		return 0, 0, false
+	}
+	return int(ws.Col), int(ws.Row), true
+}
+
+//  Put the terminal fd into raw mode. Input is read a byte at a time and not
+//  echoed, and signal characters (e.g. Ctrl-C) are read as input. Output
+//  processing is left on, so "\n" is still written as "\r\n". The returned
+//  function restores the previous mode.
+func makeRaw(fd uintptr) (restore func() error, err error) {
+	old, err := getTermios(fd)
+	if err != nil {
+		return nil, err
+	}
+	raw := *old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := setTermios(fd, &raw); err != nil {
+		return nil, err
+	}
+	return func() error { return setTermios(fd, old) }, nil
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+
+package goline
+
+/*
+ *  Filename:    term_other.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:47:30 UTC 2026
+ *  Description: Terminal control stubs for unsupported platforms.
+ */
+
+//  Terminal control is only supported on Linux. Elsewhere, input is always
+//  treated as if it does not come from a terminal.
+func isTerminal(fd uintptr) bool { return false }
+
+func termSize(fd uintptr) (cols, rows int, ok bool) { return 0, 0, false }
+
+func makeRaw(fd uintptr) (restore func() error, err error) {
+	return nil, NewError("Terminal raw mode is not supported")
+}
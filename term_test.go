@@ -0,0 +1,112 @@
+package goline
+/*
+ *  Filename:    term_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:47:30 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "strings"
+    "testing"
+)
+
+func TestHiddenTwice(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("hunter2\nhunter3\nsecret\nsecret\nnext\n"), out)
+    var pass string
+    err := s.Ask(&pass, "Password: ", func(q *Question) {
+        q.Hidden = true
+        q.Mask = '*'
+        q.Twice = true
+    })
+    if err != nil || pass != "secret" {
+        T.Errorf("Unexpected answer %#v %v", pass, err)
+    }
+    if !strings.Contains(out.String(), "Answers do not match") {
+        T.Errorf("Mismatch not reported %#v", out.String())
+    }
+    if strings.Contains(out.String(), "secret") {
+        T.Errorf("Secret was echoed %#v", out.String())
+    }
+
+    // Consumed input is wiped from the buffer, the rest is kept.
+    if i := bytes.Index(s.r.buf, []byte("secret")); i >= 0 {
+        T.Errorf("Secret left in the input buffer")
+    }
+    var next string
+    if s.Ask(&next, "Next? ", nil); next != "next" {
+        T.Errorf("Unexpected answer %#v", next)
+    }
+}
+
+func TestAppendSecret(T *testing.T) {
+    p := make([]byte, 0, 2)
+    old := p[:2]
+    for _, c := range []byte("abc") {
+        p = appendSecret(p, c)
+    }
+    if string(p) != "abc" {
+        T.Errorf("Unexpected secret %#v", string(p))
+    }
+    if old[0] != 0 || old[1] != 0 {
+        T.Errorf("Old buffer was not wiped %#v", old)
+    }
+}
+
+func TestReadSecretPiped(T *testing.T) {
+    secret := strings.Repeat("hunter2", 20)
+    s := NewSession(strings.NewReader(secret+"\nnext\n"), new(bytes.Buffer))
+    var outgrown [][]byte
+    line, err := s.readLineFunc(context.Background(), func(p []byte, c byte) []byte {
+        q := appendSecret(p, c)
+        if len(p) > 0 && &q[0] != &p[0] {
+            outgrown = append(outgrown, p)
+        }
+        return q
+    })
+    if err != nil || string(line) != secret {
+        T.Fatalf("Unexpected secret %#v %v", string(line), err)
+    }
+    if len(outgrown) == 0 {
+        T.Fatalf("Secret buffer never grew")
+    }
+    for _, p := range outgrown {
+        if strings.Trim(string(p), "\x00") != "" {
+            T.Errorf("Outgrown buffer was not wiped %#v", string(p))
+        }
+    }
+
+    var pw string
+    var hq *Question
+    s = NewSession(strings.NewReader(secret+"\n"), new(bytes.Buffer))
+    err = s.Ask(&pw, "Password: ", func(q *Question) {
+        q.Hidden = true
+        hq = q
+    })
+    if err != nil || pw != secret {
+        T.Errorf("Unexpected password %#v %v", pw, err)
+    }
+    if hq.val != nil {
+        T.Errorf("Secret kept by the Question")
+    }
+}
+
+func TestSessionWidth(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(""), out)
+    T.Setenv("COLUMNS", "")
+    if w := s.Width(); w != 80 {
+        T.Errorf("Unexpected default width %d", w)
+    }
+    T.Setenv("COLUMNS", "12")
+    if w := s.Width(); w != 12 {
+        T.Errorf("Unexpected width %d", w)
+    }
+    s.List([]string{"cat", "dog", "go fish"}, ColumnsAcross, nil)
+    if out.String() != "cat\ndog\ngo fish\n" {
+        T.Errorf("List not wrapped to COLUMNS %#v", out.String())
+    }
+}
@@ -0,0 +1,114 @@
+package goline
+
+/*
+ *  Filename:    typed.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:43:28 UTC 2026
+ *  Description: A statically typed interface to Ask.
+ */
+import (
+	"cmp"
+	"context"
+	"fmt"
+)
+
+//  A set of answers of type T. Unlike an AnswerSet, the type of a set's
+//  members is checked at compile time. See WithSet.
+type AnswerSetOf[T any] interface {
+	Has(x T) bool
+	String() string
+}
+
+//  An option configuring a question asked with AskT.
+type Option[T any] func(*typedQuestion[T])
+
+type typedQuestion[T any] struct {
+	*Question
+	sets []AnswerSetOf[T]
+}
+
+//  Use v as the answer when the user inputs an empty string.
+func WithDefault[T any](v T) Option[T] {
+	return func(q *typedQuestion[T]) { q.Default = v }
+}
+
+//  Use v as the answer without prompting the user, provided that it
+//  satisfies all the set membership tests of the question.
+func WithFirstAnswer[T any](v T) Option[T] {
+	return func(q *typedQuestion[T]) { q.FirstAnswer = v }
+}
+
+//  Require that the answer be contained in set. Multiple sets may be given,
+//  in which case the answer must be contained in all of them.
+func WithSet[T any](set AnswerSetOf[T]) Option[T] {
+	return func(q *typedQuestion[T]) { q.sets = append(q.sets, set) }
+}
+
+//  Configure the underlying Question directly, as with the config function
+//  given to Ask.
+func WithConfig[T any](config func(*Question)) Option[T] {
+	return func(q *typedQuestion[T]) { config(q.Question) }
+}
+
+//  Prompt the user for a value of type T. AskT is a type-safe alternative to
+//  Ask; defaults, first answers and answer sets given as options must have
+//  type T, so mismatches are compile errors.
+//      port, err := goline.AskT("Port? ",
+//          goline.WithDefault(8080),
+//          goline.WithSet[int](goline.RangeOf[int]{1, 65535}))
+func AskT[T any](msg string, opts ...Option[T]) (T, error) {
+	return SessionAskT(DefaultSession, context.Background(), msg, opts...)
+}
+
+//  Like AskT, but the prompt is abandoned when ctx is done. See AskContext.
+func AskTContext[T any](ctx context.Context, msg string, opts ...Option[T]) (T, error) {
+	return SessionAskT(DefaultSession, ctx, msg, opts...)
+}
+
+//  Like AskTContext, but the user is prompted on s.Out and input is read
+//  from s.In.
+func SessionAskT[T any](s *Session, ctx context.Context, msg string, opts ...Option[T]) (T, error) {
+	var val T
+	err := s.AskContext(ctx, &val, msg, func(q *Question) {
+		tq := &typedQuestion[T]{Question: q}
+		for _, opt := range opts {
+			opt(tq)
+		}
+		if len(tq.sets) > 0 {
+			q.check = func(dest interface{}) error {
+				x := *dest.(*T)
+				for _, set := range tq.sets {
+					if !set.Has(x) {
+						return q.makeErrorNotInSet(set, x)
+					}
+				}
+				return nil
+			}
+		}
+	})
+	return val, err
+}
+
+//  A simple set of comparable values.
+type SetOf[T comparable] []T
+
+//  Compares x to each element in set.
+func (set SetOf[T]) Has(x T) bool {
+	for _, y := range set {
+		if x == y {
+			return true
+		}
+	}
+	return false
+}
+
+func (set SetOf[T]) String() string { return fmt.Sprintf("set %v", []T(set)) }
+
+//  A range of ordered values [Min, Max].
+type RangeOf[T cmp.Ordered] struct {
+	Min, Max T
+}
+
+func (r RangeOf[T]) Has(x T) bool   { return x >= r.Min && x <= r.Max }
+func (r RangeOf[T]) String() string { return fmt.Sprintf("range [%v, %v]", r.Min, r.Max) }
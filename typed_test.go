@@ -0,0 +1,85 @@
+package goline
+/*
+ *  Filename:    typed_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:43:28 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "strings"
+    "testing"
+)
+
+func TestAskT(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("70000\n443\n"), out)
+    port, err := SessionAskT(s, context.Background(), "Port? ",
+        WithDefault(8080),
+        WithSet[int](RangeOf[int]{1, 65535}))
+    if err != nil {
+        T.Fatalf("Unexpected error %v", err)
+    }
+    if port != 443 {
+        T.Errorf("Unexpected answer %d", port)
+    }
+    if !strings.Contains(out.String(), "range [1, 65535] (70000)") {
+        T.Errorf("Set error not printed %#v", out.String())
+    }
+}
+
+func TestAskTDefault(T *testing.T) {
+    s := NewSession(strings.NewReader("\n"), new(bytes.Buffer))
+    name, err := SessionAskT(s, context.Background(), "Name? ", WithDefault("gopher"))
+    if err != nil || name != "gopher" {
+        T.Errorf("Unexpected result %#v %v", name, err)
+    }
+}
+
+func TestAskTFirstAnswer(T *testing.T) {
+    s := NewSession(strings.NewReader("b\n"), new(bytes.Buffer))
+    set := SetOf[string]{"a", "b"}
+    x, err := SessionAskT(s, context.Background(), "? ", WithFirstAnswer("a"), WithSet[string](set))
+    if err != nil || x != "a" {
+        T.Errorf("Unexpected result %#v %v", x, err)
+    }
+    // A first answer outside the set falls back to prompting.
+    x, err = SessionAskT(s, context.Background(), "? ", WithFirstAnswer("c"), WithSet[string](set))
+    if err != nil || x != "b" {
+        T.Errorf("Unexpected result %#v %v", x, err)
+    }
+}
+
+func TestAskTRejected(T *testing.T) {
+    // Rejected answers are not left in the destination.
+    s := NewSession(strings.NewReader("70000\n"), new(bytes.Buffer))
+    port, err := SessionAskT(s, context.Background(), "Port? ",
+        WithFirstAnswer(0),
+        WithSet[int](RangeOf[int]{1, 65535}))
+    if err == nil || port != 0 {
+        T.Errorf("Unexpected result %d %v", port, err)
+    }
+    var x int8 = 7
+    s = NewSession(strings.NewReader("300\n"), new(bytes.Buffer))
+    if err := s.Ask(&x, "? ", nil); err == nil || x != 7 {
+        T.Errorf("Unexpected result %d %v", x, err)
+    }
+}
+
+func TestAskTSlice(T *testing.T) {
+    s := NewSession(strings.NewReader("1,2\n"), new(bytes.Buffer))
+    xs, err := SessionAskT(s, context.Background(), "? ",
+        WithConfig[[]float64](func(q *Question) { q.Sep = "," }))
+    if err != nil || len(xs) != 2 || xs[0] != 1 || xs[1] != 2 {
+        T.Errorf("Unexpected result %#v %v", xs, err)
+    }
+}
+
+func TestAskTUnusable(T *testing.T) {
+    s := NewSession(strings.NewReader("1\n"), new(bytes.Buffer))
+    if _, err := SessionAskT[complex128](s, context.Background(), "? "); err == nil {
+        T.Errorf("No error for an unusable destination type")
+    }
+}
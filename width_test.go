@@ -0,0 +1,57 @@
+package goline
+/*
+ *  Filename:    width_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:56:52 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "context"
+    "strings"
+    "testing"
+)
+
+func TestStringWidth(T *testing.T) {
+    for _, test := range []struct {
+        str   string
+        width int
+    }{
+        {"", 0},
+        {"go fish", 7},
+        {"café", 4},
+        {"café", 4},
+        {"日本語", 6},
+        {"ｶﾀｶﾅ", 4},
+        {"ＡＢ", 4},
+        {"한국어", 6},
+        {"가", 2},
+        {"🍣 sushi", 8},
+        {"👍️", 2},
+        {"a‍b", 2},
+        {"\x1b\t", 0},
+    } {
+        if w := StringWidth(test.str); w != test.width {
+            T.Errorf("Unexpected width of %#v: %d != %d", test.str, w, test.width)
+        }
+    }
+}
+
+func TestListWideItems(T *testing.T) {
+    out := new(bytes.Buffer)
+    fList(out, []string{"寿司", "sushi", "ラーメン"}, ColumnsAcross, nil, 20)
+    expect := "寿司     sushi\nラーメン\n"
+    if out.String() != expect {
+        T.Errorf("Unexpected output %#v != %#v", out.String(), expect)
+    }
+}
+
+func TestLineEditorWideRunes(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader("日本\x1b[D\r"), out)
+    s.editLine(context.Background(), nil, "? ")
+    if !strings.HasSuffix(out.String(), "\x1b[4D日本\x1b[K\x1b[2D" + "\x1b[2D日本\x1b[K\n") {
+        T.Errorf("Unexpected output %#v", out.String())
+    }
+}
@@ -0,0 +1,188 @@
+package goline
+
+/*
+ *  Filename:    wrap.go
+ *  Package:     goline
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:59:21 UTC 2026
+ *  Description: Wrapping and indentation of messages.
+ */
+import (
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//  A Session's WrapAt value that wraps messages at the terminal width (see
+//  Session.Width).
+const WrapAuto = -1
+
+//  The number of spaces per indentation level if a Session's IndentSize is
+//  not positive.
+const DefaultIndentSize = 3
+
+//  Increase the indentation of messages printed by Say and List. Each level
+//  indents by the Session's IndentSize.
+//      goline.Say("Commands:")
+//      goline.Indent()
+//      goline.List(commands, goline.Rows, nil)
+//      goline.Outdent()
+func Indent() { DefaultSession.Indent() }
+
+//  Decrease the indentation of messages. See Indent.
+func Outdent() { DefaultSession.Outdent() }
+
+//  Like Indent, but for messages written to s.Out.
+func (s *Session) Indent() { s.indent++ }
+
+//  Like Outdent, but for messages written to s.Out. Outdent does nothing if
+//  messages are not indented.
+func (s *Session) Outdent() {
+	if s.indent > 0 {
+		s.indent--
+	}
+}
+
+//  The current indentation level of s.
+func (s *Session) IndentLevel() int { return s.indent }
+
+//  The indentation prefixed to each line written by s.
+func (s *Session) indentation() string {
+	size := s.IndentSize
+	if size <= 0 {
+		size = DefaultIndentSize
+	}
+	return strings.Repeat(" ", size*s.indent)
+}
+
+//  The width available to text after indentation.
+func (s *Session) textWidth() int {
+	w := s.Width() - len(s.indentation())
+	if w < 1 {
+		return 1
+	}
+	return w
+}
+
+//  Returns a writer for a message starting on a new line of s.Out. Each
+//  non-empty line written is indented. Output is buffered while it is being
+//  paged.
+func (s *Session) writer() io.Writer {
+	var out io.Writer = s.Out
+	if s.paging != nil {
+		out = s.paging
+	}
+	if s.indent == 0 {
+		return out
+	}
+	return &indentWriter{w: out, indent: s.indentation()}
+}
+
+type indentWriter struct {
+	w      io.Writer
+	indent string
+	mid    bool
+}
+
+func (w *indentWriter) Write(p []byte) (int, error) {
+	var buf []byte
+	for _, c := range p {
+		if !w.mid && c != '\n' {
+			buf = append(buf, w.indent...)
+		}
+		buf = append(buf, c)
+		w.mid = c != '\n'
+	}
+	if _, err := w.w.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+//  Wrap msg at s.WrapAt columns (less any indentation). Lines are broken
+//  between words; explicit newlines, the leading whitespace of each line and
+//  trailing whitespace are kept. Continuation lines get the same leading
+//  whitespace as the line they continue. Words wider than the wrap width are
+//  not broken.
+func (s *Session) wrap(msg string) string {
+	width := s.WrapAt
+	switch {
+	case width == WrapAuto:
+		width = s.Width()
+	case width <= 0:
+		return msg
+	}
+	width -= len(s.indentation())
+	body := strings.TrimRightFunc(msg, unicode.IsSpace)
+	tail := msg[len(body):]
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(line, width)
+	}
+	return strings.Join(lines, "\n") + tail
+}
+
+func wrapLine(line string, width int) string {
+	text := strings.TrimLeftFunc(line, unicode.IsSpace)
+	lead := line[:len(line)-len(text)]
+	words := markupFields(text)
+	if len(words) == 0 {
+		return line
+	}
+	var b strings.Builder
+	b.WriteString(lead + words[0])
+	col := StringWidth(lead) + markupWidth(words[0])
+	for _, word := range words[1:] {
+		w := markupWidth(word)
+		if col+1+w > width {
+			b.WriteString("\n" + lead)
+			col = StringWidth(lead)
+		} else {
+			b.WriteString(" ")
+			col++
+		}
+		b.WriteString(word)
+		col += w
+	}
+	return b.String()
+}
+
+//  The display width of text containing style markup.
+func markupWidth(text string) int { return StringWidth(renderMarkup(text, false)) }
+
+//  Split text into words at whitespace outside of style markup tags.
+func markupFields(text string) []string {
+	var words []string
+	var word strings.Builder
+	for i := 0; i < len(text); {
+		if strings.HasPrefix(text[i:], "{{{{") {
+			word.WriteString("{{{{")
+			i += 4
+			continue
+		}
+		if strings.HasPrefix(text[i:], "{{") {
+			if j := strings.Index(text[i:], "}}"); j > 0 {
+				if _, ok := styleCodes(text[i+2:i+j], 0); ok {
+					word.WriteString(text[i : i+j+2])
+					i += j + 2
+					continue
+				}
+			}
+		}
+		r, n := utf8.DecodeRuneInString(text[i:])
+		if unicode.IsSpace(r) {
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+		} else {
+			word.WriteString(text[i : i+n])
+		}
+		i += n
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+	return words
+}
@@ -0,0 +1,55 @@
+package goline
+/*
+ *  Filename:    wrap_test.go
+ *  Author:      Bryan Matsuo <bmatsuo@soe.ucsc.edu>
+ *  Created:     Sat Oct 17 00:59:21 UTC 2026
+ *  Description:
+ *  Usage:       gotest
+ */
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+func TestSayWrap(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(""), out)
+    s.WrapAt = 20
+    s.Say("The quick brown fox jumps over the lazy dog.\n\n  - an indented item that wraps\nName? ")
+    expect := "The quick brown fox\njumps over the lazy\ndog.\n\n" +
+        "  - an indented item\n  that wraps\nName? "
+    if out.String() != expect {
+        T.Errorf("Unexpected output %#v != %#v", out.String(), expect)
+    }
+
+    out.Reset()
+    s.WrapAt = 10
+    s.Say("{{bold red}}Warning:{{/}} disk full")
+    if out.String() != "Warning:\ndisk full\n" {
+        T.Errorf("Markup not ignored when wrapping %#v", out.String())
+    }
+}
+
+func TestSayIndent(T *testing.T) {
+    out := new(bytes.Buffer)
+    s := NewSession(strings.NewReader(""), out)
+    s.IndentSize = 2
+    s.Say("Commands:")
+    s.Indent()
+    s.List([]string{"ls", "cd"}, Rows, nil)
+    s.Indent()
+    s.WrapAt = 10
+    s.Say("nested text\n\nwrapped")
+    s.Outdent()
+    s.Outdent()
+    s.Outdent()
+    s.Say("Done")
+    expect := "Commands:\n  ls\n  cd\n    nested\n    text\n\n    wrapped\nDone\n"
+    if out.String() != expect {
+        T.Errorf("Unexpected output %#v != %#v", out.String(), expect)
+    }
+    if s.IndentLevel() != 0 {
+        T.Errorf("Unexpected indentation level %d", s.IndentLevel())
+    }
+}